@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/infor-design/selfservice/reposerver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	OUTPUT_TEXT  = "text"
+	OUTPUT_SARIF = "sarif"
+
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int32 `json:"startLine"`
+}
+
+func NewLintCommand() *cobra.Command {
+	var (
+		output   string
+		root     string
+		resource bool
+	)
+
+	var command = &cobra.Command{
+		Use:   "lint DIR",
+		Short: "Check the form manifests of an application",
+		Long:  "Lint checks the schema, uischema and data manifests of an application directory: the manifests parse, the schema is a valid JSON Schema, the data validates against it, the uischema scopes exist, and the data renders a job the server accepts.  It exits with an error when any check fails.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			// Either may be relative to the working directory.
+			absRoot, err := filepath.Abs(root)
+
+			if err != nil {
+				return err
+			}
+
+			absDir, err := filepath.Abs(args[0])
+
+			if err != nil {
+				return err
+			}
+
+			dir, err := filepath.Rel(absRoot, absDir)
+
+			if err != nil {
+				return err
+			}
+
+			findings, err := reposerver.LintDir(absRoot, filepath.ToSlash(dir), resource)
+
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case OUTPUT_TEXT:
+				writeText(c.OutOrStdout(), findings)
+			case OUTPUT_SARIF:
+				err = writeSarif(c.OutOrStdout(), findings)
+			default:
+				return errors.Errorf("unknown output %q, expected text or sarif", output)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			for _, finding := range findings {
+				if finding.Level == reposerver.LEVEL_ERROR {
+					return errors.New("lint failed")
+				}
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", OUTPUT_TEXT, "Output format, text or sarif")
+	command.Flags().StringVar(&root, "root", ".", "Root of the repo, which $refs and reported paths are relative to")
+	command.Flags().BoolVar(&resource, "resource", false, "The application has a status check and creates a resource rather than a job")
+
+	return command
+}
+
+func writeText(w io.Writer, findings []*reposerver.Finding) {
+	errorCount := 0
+
+	for _, finding := range findings {
+		location := finding.File
+
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%s:%d", finding.File, finding.Line)
+		}
+
+		if finding.Level == reposerver.LEVEL_ERROR {
+			errorCount++
+		}
+
+		fmt.Fprintf(w, "%s: %s: %s [%s]\n", location, finding.Level, finding.Message, finding.Rule)
+	}
+
+	fmt.Fprintf(w, "%d errors, %d warnings\n", errorCount, len(findings)-errorCount)
+}
+
+func writeSarif(w io.Writer, findings []*reposerver.Finding) error {
+	driver := sarifDriver{Name: "selfservice"}
+	ruleIds := make([]string, 0, len(reposerver.Rules))
+
+	for id := range reposerver.Rules {
+		ruleIds = append(ruleIds, id)
+	}
+
+	sort.Strings(ruleIds)
+
+	for _, id := range ruleIds {
+		driver.Rules = append(driver.Rules, sarifRule{Id: id, ShortDescription: sarifMessage{Text: reposerver.Rules[id]}})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+
+	for _, finding := range findings {
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: finding.File}}
+
+		if finding.Line > 0 {
+			location.Region = &sarifRegion{StartLine: finding.Line}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleId:    finding.Rule,
+			Level:     finding.Level,
+			Message:   sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/infor-design/selfservice/reposerver"
+)
+
+func TestWriteSarif(t *testing.T) {
+	findings := []*reposerver.Finding{
+		{Rule: reposerver.RULE_MANIFEST, Level: reposerver.LEVEL_ERROR, File: "app/schema.yaml", Line: 3, Message: "bad <indent>"},
+		{Rule: reposerver.RULE_DATA, Level: reposerver.LEVEL_WARNING, File: "app", Message: "no data manifest"},
+	}
+	var out bytes.Buffer
+
+	if err := writeSarif(&out, findings); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "bad <indent>") {
+		t.Errorf("html in the message is escaped: %s", out.String())
+	}
+
+	var log sarifLog
+
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("got log %+v", log)
+	}
+
+	run := log.Runs[0]
+
+	if len(run.Tool.Driver.Rules) != len(reposerver.Rules) {
+		t.Errorf("got %d rules, want %d", len(run.Tool.Driver.Rules), len(reposerver.Rules))
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("got results %+v", run.Results)
+	}
+
+	first := run.Results[0]
+
+	if first.RuleId != reposerver.RULE_MANIFEST || first.Level != "error" || first.Locations[0].PhysicalLocation.ArtifactLocation.Uri != "app/schema.yaml" || first.Locations[0].PhysicalLocation.Region.StartLine != 3 {
+		t.Errorf("got result %+v", first)
+	}
+
+	if region := run.Results[1].Locations[0].PhysicalLocation.Region; region != nil {
+		t.Errorf("got region %+v for a finding without a line", region)
+	}
+}
+
+func TestWriteSarifEmpty(t *testing.T) {
+	var out bytes.Buffer
+
+	if err := writeSarif(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), `"results": []`) {
+		t.Errorf("results are not an empty array: %s", out.String())
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var out bytes.Buffer
+	writeText(&out, []*reposerver.Finding{
+		{Rule: reposerver.RULE_MANIFEST, Level: reposerver.LEVEL_ERROR, File: "app/schema.yaml", Line: 3, Message: "bad indent"},
+		{Rule: reposerver.RULE_DATA, Level: reposerver.LEVEL_WARNING, File: "app", Message: "no data manifest"},
+	})
+	want := "app/schema.yaml:3: error: bad indent [manifest]\napp: warning: no data manifest [data]\n1 errors, 1 warnings\n"
+
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func runLint(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	command := NewLintCommand()
+	command.SetOut(&out)
+	command.SetErr(&out)
+	command.SetArgs(args)
+	err := command.Execute()
+	return out.String(), err
+}
+
+func TestLintCommand(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "apps", "backup"), 0755)
+	os.WriteFile(filepath.Join(root, "apps", "backup", "schema.json"), []byte(`{"type": "object"}`), 0644)
+	os.WriteFile(filepath.Join(root, "apps", "backup", "data.json"), []byte(`{
+		"metadata.name": "backup",
+		"spec.template.spec.containers[0].name": "main",
+		"spec.template.spec.containers[0].image": "busybox",
+		"spec.template.spec.restartPolicy": "Never"
+	}`), 0644)
+	os.MkdirAll(filepath.Join(root, "apps", "broken"), 0755)
+	os.WriteFile(filepath.Join(root, "apps", "broken", "schema.json"), []byte(`{"type": "object"`), 0644)
+
+	wd, err := os.Getwd()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	// An absolute directory with the default relative root.
+	if out, err := runLint(t, filepath.Join(root, "apps", "backup")); err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+
+	if out, err := runLint(t, "--root", root, "apps/backup"); err != nil {
+		t.Fatalf("%s: %s", err, out)
+	}
+
+	out, err := runLint(t, "-o", OUTPUT_SARIF, filepath.Join(root, "apps", "broken"))
+
+	if err == nil || err.Error() != "lint failed" {
+		t.Fatalf("got error %v", err)
+	}
+
+	if !strings.Contains(out, `"uri": "apps/broken/schema.json"`) {
+		t.Errorf("finding is not relative to the root: %s", out)
+	}
+
+	if _, err := runLint(t, "-o", "xml", "apps/backup"); err == nil {
+		t.Error("accepted an unknown output")
+	}
+
+	if _, err := runLint(t, "--root", filepath.Join(root, "apps", "backup"), "apps/broken"); err == nil {
+		t.Error("linted a directory outside of the root")
+	}
+}
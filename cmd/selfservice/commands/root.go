@@ -15,5 +15,8 @@ func NewCommand() *cobra.Command {
 		SilenceUsage:      true,
 	}
 
+	command.AddCommand(NewLintCommand())
+	command.AddCommand(NewSyncCommand())
+
 	return command
 }
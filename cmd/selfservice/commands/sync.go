@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type syncProgress struct {
+	Phase   string `json:"phase"`
+	Message string `json:"message"`
+}
+
+type syncedRepo struct {
+	Url         string `json:"url"`
+	Hash        string `json:"hash"`
+	PendingHash string `json:"pending_hash"`
+}
+
+type syncError struct {
+	Message string `json:"message"`
+}
+
+func NewSyncCommand() *cobra.Command {
+	var server string
+
+	var command = &cobra.Command{
+		Use:   "sync REPO_ID",
+		Short: "Sync a repo and show its progress",
+		Long:  "Sync has the server sync a repo, printing the phases of the sync and the progress messages of the git remote until it is done.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			endpoint, err := url.JoinPath(server, "repos", args[0], "sync", "events")
+
+			if err != nil {
+				return err
+			}
+
+			response, err := http.Post(endpoint, "application/json", nil)
+
+			if err != nil {
+				return err
+			}
+
+			defer response.Body.Close()
+
+			if response.StatusCode != http.StatusOK {
+				var syncErr syncError
+				json.NewDecoder(response.Body).Decode(&syncErr)
+				return errors.Errorf("sync failed: %s %s", response.Status, syncErr.Message)
+			}
+
+			return readSyncEvents(c.OutOrStdout(), response.Body)
+		},
+	}
+
+	command.Flags().StringVar(&server, "server", "http://localhost:8080", "Url of the selfservice server")
+
+	return command
+}
+
+// readSyncEvents prints the server-sent events of a sync until it is done.
+func readSyncEvents(w io.Writer, body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	event := ""
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "event: ") {
+			event = strings.TrimPrefix(line, "event: ")
+			continue
+		}
+
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		data := strings.TrimPrefix(line, "data: ")
+
+		switch event {
+		case "progress":
+			var progress syncProgress
+			err := json.Unmarshal([]byte(data), &progress)
+
+			if err != nil {
+				return err
+			}
+
+			if progress.Message == "" {
+				fmt.Fprintf(w, "%s...\n", progress.Phase)
+			} else {
+				fmt.Fprintf(w, "  %s\n", progress.Message)
+			}
+		case "done":
+			var repo syncedRepo
+			err := json.Unmarshal([]byte(data), &repo)
+
+			if err != nil {
+				return err
+			}
+
+			if repo.PendingHash != "" {
+				fmt.Fprintf(w, "fetched %s at %s, pending promotion from %s\n", repo.Url, repo.PendingHash, repo.Hash)
+				return nil
+			}
+
+			fmt.Fprintf(w, "synced %s at %s\n", repo.Url, repo.Hash)
+			return nil
+		case "error":
+			var syncErr syncError
+			json.Unmarshal([]byte(data), &syncErr)
+			return errors.Errorf("sync failed: %s", syncErr.Message)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return errors.New("sync ended without a result")
+}
@@ -1,23 +1,47 @@
 package commands
 
 import (
+	"strconv"
+
+	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/infor-design/selfservice/pkg/utils"
 	"github.com/infor-design/selfservice/server"
 	"github.com/spf13/cobra"
 )
 
 func NewCommand() *cobra.Command {
+	kubeConfig := client.NewConfig()
+	impersonate, _ := strconv.ParseBool(utils.GetEnv("IMPERSONATE_USERS", "false"))
+	userHeader := utils.GetEnv("AUTH_USER_HEADER", "X-Forwarded-User")
+	groupsHeader := utils.GetEnv("AUTH_GROUPS_HEADER", "X-Forwarded-Groups")
+
 	var command = &cobra.Command{
 		Use:               "selfservice-server",
 		Short:             "Run the selfservice API server",
 		Long:              "The API server is a REST server which exposes the API consumed by the Web UI, and CLI.  This command runs API server in the foreground.  It can be configured by following options.",
 		DisableAutoGenTag: true,
 		Run: func(c *cobra.Command, args []string) {
-			serverConfig := server.ServerConfig{}
+			serverConfig := server.ServerConfig{
+				Kube:         kubeConfig,
+				Impersonate:  impersonate,
+				UserHeader:   userHeader,
+				GroupsHeader: groupsHeader,
+			}
 			server := server.NewServer(serverConfig)
 			server.Init()
 			server.Run()
 		},
 	}
 
+	command.Flags().StringVar(&kubeConfig.Kubeconfig, "kubeconfig", kubeConfig.Kubeconfig, "Path to a kubeconfig file, instead of the in-cluster service account")
+	command.Flags().StringVar(&kubeConfig.Context, "context", kubeConfig.Context, "Name of the kubeconfig context to use")
+	command.Flags().Float32Var(&kubeConfig.QPS, "kube-qps", kubeConfig.QPS, "Maximum queries per second to the Kubernetes API")
+	command.Flags().IntVar(&kubeConfig.Burst, "kube-burst", kubeConfig.Burst, "Maximum burst of queries to the Kubernetes API")
+	command.Flags().StringVar(&kubeConfig.ImpersonateUser, "as", kubeConfig.ImpersonateUser, "Username to impersonate for Kubernetes operations")
+	command.Flags().StringSliceVar(&kubeConfig.ImpersonateGroups, "as-group", kubeConfig.ImpersonateGroups, "Group to impersonate for Kubernetes operations, can be repeated")
+	command.Flags().BoolVar(&impersonate, "impersonate", impersonate, "Create job objects as the requesting user, as reported by the authenticating proxy")
+	command.Flags().StringVar(&userHeader, "user-header", userHeader, "Header carrying the authenticated user")
+	command.Flags().StringVar(&groupsHeader, "groups-header", groupsHeader, "Header carrying the groups of the authenticated user")
+
 	return command
 }
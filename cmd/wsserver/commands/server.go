@@ -1,20 +1,35 @@
 package commands
 
 import (
+	"github.com/infor-design/selfservice/pkg/client"
 	"github.com/infor-design/selfservice/wsserver"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 func NewCommand() *cobra.Command {
+	kubeConfig := client.NewConfig()
+
 	var command = &cobra.Command{
 		Use:               "selfservice-server",
 		Short:             "Run the selfservice API server",
 		Long:              "The API server is a REST server which exposes the API consumed by the Web UI, and CLI.  This command runs API server in the foreground.  It can be configured by following options.",
 		DisableAutoGenTag: true,
 		Run: func(c *cobra.Command, args []string) {
-			wsserver.Run()
+			kube, err := client.NewClient(kubeConfig)
+
+			if err != nil {
+				log.Fatalf("kubernetes client: %s", err)
+			}
+
+			wsserver.Run(kube)
 		},
 	}
 
+	command.Flags().StringVar(&kubeConfig.Kubeconfig, "kubeconfig", kubeConfig.Kubeconfig, "Path to a kubeconfig file, instead of the in-cluster service account")
+	command.Flags().StringVar(&kubeConfig.Context, "context", kubeConfig.Context, "Name of the kubeconfig context to use")
+	command.Flags().Float32Var(&kubeConfig.QPS, "kube-qps", kubeConfig.QPS, "Maximum queries per second to the Kubernetes API")
+	command.Flags().IntVar(&kubeConfig.Burst, "kube-burst", kubeConfig.Burst, "Maximum burst of queries to the Kubernetes API")
+
 	return command
 }
@@ -1,7 +1,12 @@
 package application
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/infor-design/selfservice/pkg/db"
+	"github.com/pkg/errors"
+	"gorm.io/gorm/clause"
 )
 
 func NewService(db *db.Connection) *Service {
@@ -16,17 +21,44 @@ func (s *Service) List() []Application {
 	return applications
 }
 
-func (s *Service) Create(payload Application) Application {
-	application := db.Application{Name: payload.Name, RepoID: payload.RepoID, ManifestPath: payload.ManifestPath}
-	s.db.Create(&application)
+// ListByCluster returns the applications bound to a cluster. Applications
+// without any cluster belong to the default cluster (0).
+func (s *Service) ListByCluster(clusterId uint) []db.Application {
+	var applications []db.Application
+	bound := s.db.Table("application_clusters").Select("application_id")
+
+	if clusterId == 0 {
+		s.db.Where("id NOT IN (?) AND archived_at IS NULL", bound).Find(&applications)
+	} else {
+		s.db.Where("id IN (?) AND archived_at IS NULL", bound.Where("cluster_id = ?", clusterId)).Find(&applications)
+	}
+
+	return applications
+}
+
+func (s *Service) ListByRepo(repoId uint) []db.Application {
+	var applications []db.Application
+	s.db.Where("repo_id = ? AND archived_at IS NULL", repoId).Find(&applications)
+	return applications
+}
+
+func (s *Service) Create(payload Application) (Application, error) {
+	application := db.Application{Name: payload.Name, RepoID: payload.RepoID, ManifestPath: payload.ManifestPath, Ref: payload.Ref, StatusCheck: payload.StatusCheck}
+	err := s.db.Create(&application).Error
+
+	if err != nil {
+		return payload, err
+	}
+
 	payload.Id = int(application.ID)
 	payload.Created_At = application.CreatedAt.String()
-	return payload
+	err = s.SetClusters(application, payload.Clusters)
+	return payload, err
 }
 
 func (s *Service) Get(id uint) (db.Application, error) {
 	application := db.Application{}
-	err := s.db.First(&application, id).Error
+	err := s.db.Preload("Clusters").First(&application, id).Error
 
 	if err != nil {
 		return application, err
@@ -36,17 +68,108 @@ func (s *Service) Get(id uint) (db.Application, error) {
 }
 
 func (s *Service) Update(application db.Application) error {
-	err := s.db.Save(&application).Error
+	err := s.db.Omit(clause.Associations).Save(&application).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetClusters replaces the clusters an application runs its jobs on.
+func (s *Service) SetClusters(application db.Application, clusterIds []uint) error {
+	var clusters []db.Cluster
+
+	if len(clusterIds) > 0 {
+		err := s.db.Find(&clusters, clusterIds).Error
+
+		if err != nil {
+			return err
+		}
+
+		if len(clusters) != len(clusterIds) {
+			return errors.New("unknown cluster")
+		}
+	}
+
+	return s.db.Model(&application).Association("Clusters").Replace(clusters)
+}
+
+// Reconcile creates, updates and archives the applications discovered in a
+// repo from its descriptors. Applications created by hand are left alone, and
+// the applications of invalid descriptors are kept as they are.
+func (s *Service) Reconcile(repoId uint, descriptors []Descriptor) error {
+	var discovered []db.Application
+	err := s.db.Where("repo_id = ? AND source <> ''", repoId).Find(&discovered).Error
 
 	if err != nil {
 		return err
 	}
 
+	bySource := map[string]db.Application{}
+
+	for _, application := range discovered {
+		bySource[application.Source] = application
+	}
+
+	declared := map[string]bool{}
+
+	for _, descriptor := range descriptors {
+		declared[descriptor.Source] = true
+
+		if descriptor.Invalid {
+			continue
+		}
+
+		owners, err := json.Marshal(descriptor.Owners)
+
+		if err != nil {
+			return err
+		}
+
+		application := bySource[descriptor.Source]
+		application.RepoID = repoId
+		application.Source = descriptor.Source
+		application.Name = descriptor.Name
+		application.Description = descriptor.Description
+		application.Icon = descriptor.Icon
+		application.Owners = owners
+		application.Namespace = descriptor.Namespace
+		application.ManifestPath = descriptor.ManifestPath
+		application.ArchivedAt = nil
+		err = s.db.Omit(clause.Associations).Save(&application).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+
+	for _, application := range discovered {
+		if declared[application.Source] || application.ArchivedAt != nil {
+			continue
+		}
+
+		err = s.db.Model(&application).Update("archived_at", now).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *Service) Delete(application db.Application) error {
-	err := s.db.Unscoped().Delete(&application).Error
+	err := s.db.Model(&application).Association("Clusters").Clear()
+
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Unscoped().Delete(&application).Error
 
 	if err != nil {
 		return err
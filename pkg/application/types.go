@@ -1,21 +1,51 @@
 package application
 
-import "github.com/infor-design/selfservice/pkg/db"
+import (
+	"time"
+
+	"github.com/infor-design/selfservice/pkg/db"
+	"gorm.io/datatypes"
+)
 
 type Application struct {
-	Id           int    `json:"id"`
-	Name         string `json:"name"`
-	RepoID       uint   `json:"repo_id"`
-	ManifestPath string `json:"manifest_path"`
-	Created_At   string `json:"created_at"`
-	Updated_At   string `json:"updated_at"`
-	Deleted_At   string `json:"deleted_at"`
+	Id           int            `json:"id"`
+	Name         string         `json:"name"`
+	RepoID       uint           `json:"repo_id"`
+	ManifestPath string         `json:"manifest_path"`
+	Ref          string         `json:"ref"`
+	Description  string         `json:"description"`
+	Icon         string         `json:"icon"`
+	Owners       datatypes.JSON `json:"owners"`
+	Namespace    string         `json:"namespace"`
+	Source       string         `json:"source"`
+	ArchivedAt   *time.Time     `json:"archived_at"`
+	StatusCheck  datatypes.JSON `json:"status_check"`
+	Clusters     []uint         `json:"clusters" gorm:"-"`
+	Created_At   string         `json:"created_at"`
+	Updated_At   string         `json:"updated_at"`
+	Deleted_At   string         `json:"deleted_at"`
 }
 
 type ApplicationUpdate struct {
-	Name         string `json:"name"`
-	RepoID       uint   `json:"repo_id"`
-	ManifestPath string `json:"manifest_path"`
+	Name         string         `json:"name"`
+	RepoID       uint           `json:"repo_id"`
+	ManifestPath string         `json:"manifest_path"`
+	Ref          string         `json:"ref"`
+	StatusCheck  datatypes.JSON `json:"status_check"`
+	Clusters     []uint         `json:"clusters"`
+}
+
+// Descriptor is an application declared by a selfservice.yaml in a repo,
+// identified by the path of the file.
+type Descriptor struct {
+	Source       string
+	Name         string
+	Description  string
+	Icon         string
+	Owners       []string
+	Namespace    string
+	ManifestPath string
+	Invalid      bool
 }
 
 type Service struct {
@@ -1,10 +1,14 @@
 package client
 
 import (
-	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
 
+	utils "github.com/infor-design/selfservice/pkg/utils"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -12,30 +16,121 @@ type Clientset struct {
 	*kubernetes.Clientset
 }
 
-func NewClientset() *Clientset {
-	return &Clientset{
-		connect(),
+// Config selects how the Kubernetes client connects. Unless Kubeconfig or
+// Context are set explicitly the in-cluster service account is used, falling
+// back to $KUBECONFIG and ~/.kube/config.
+type Config struct {
+	Kubeconfig        string
+	Context           string
+	QPS               float32
+	Burst             int
+	ImpersonateUser   string
+	ImpersonateGroups []string
+}
+
+func NewConfig() *Config {
+	qps, _ := strconv.ParseFloat(utils.GetEnv("KUBE_QPS", "0"), 32)
+	burst, _ := strconv.Atoi(utils.GetEnv("KUBE_BURST", "0"))
+	var groups []string
+
+	if value := utils.GetEnv("KUBE_AS_GROUPS", ""); value != "" {
+		groups = strings.Split(value, ",")
+	}
+
+	return &Config{
+		Context:           utils.GetEnv("KUBE_CONTEXT", ""),
+		QPS:               float32(qps),
+		Burst:             burst,
+		ImpersonateUser:   utils.GetEnv("KUBE_AS", ""),
+		ImpersonateGroups: groups,
 	}
 }
 
-func connect() *kubernetes.Clientset {
-	home, exists := os.LookupEnv("HOME")
-	if !exists {
-		home = "/root"
+func (c *Config) RestConfig() (*rest.Config, error) {
+	config, err := c.load()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load kubernetes configuration")
 	}
 
-	configPath := filepath.Join(home, ".kube", "config")
-	config, err := clientcmd.BuildConfigFromFlags("", configPath)
+	c.tune(config)
+
+	if c.ImpersonateUser != "" {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: c.ImpersonateUser,
+			Groups:   c.ImpersonateGroups,
+		}
+	}
+
+	return config, nil
+}
+
+func (c *Config) load() (*rest.Config, error) {
+	if c.Kubeconfig == "" && c.Context == "" {
+		config, err := rest.InClusterConfig()
+
+		if err == nil {
+			return config, nil
+		}
+
+		if err != rest.ErrNotInCluster {
+			return nil, err
+		}
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = c.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: c.Context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+}
+
+// tune applies the rate limits, which hold for every cluster the server talks to.
+func (c *Config) tune(config *rest.Config) {
+	if c.QPS > 0 {
+		config.QPS = c.QPS
+	}
+
+	if c.Burst > 0 {
+		config.Burst = c.Burst
+	}
+}
+
+// NewClient connects to the cluster selected by the config. The typed and
+// dynamic clients share the same configuration.
+func NewClient(config *Config) (*Client, error) {
+	restConfig, err := config.RestConfig()
 
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
+	return NewClientForConfig(restConfig)
+}
+
+func NewClientForConfig(config *rest.Config) (*Client, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return clientset
+	dynamicClient, err := dynamic.NewForConfig(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Clientset: clientset,
+		dynamic:   dynamicClient,
+		config:    config,
+	}, nil
+}
+
+func (c *Client) Dynamic() dynamic.Interface {
+	return c.dynamic
+}
+
+func (c *Client) Typed() *Clientset {
+	return &Clientset{c.Clientset}
 }
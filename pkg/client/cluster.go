@@ -0,0 +1,91 @@
+package client
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+)
+
+const DefaultCluster uint = 0
+
+// Clusters keeps a client per cluster, connecting on first use. The default
+// cluster is the one the server itself is configured for; registered
+// clusters are resolved through configFor.
+type Clusters struct {
+	mu        sync.Mutex
+	config    *Config
+	clients   map[uint]*Client
+	stops     map[uint]chan struct{}
+	configFor func(clusterId uint) (*rest.Config, error)
+	connected func(clusterId uint, client *Client, stop chan struct{})
+}
+
+func NewClusters(config *Config, configFor func(clusterId uint) (*rest.Config, error), connected func(clusterId uint, client *Client, stop chan struct{})) *Clusters {
+	return &Clusters{
+		config:    config,
+		clients:   map[uint]*Client{},
+		stops:     map[uint]chan struct{}{},
+		configFor: configFor,
+		connected: connected,
+	}
+}
+
+// Get returns the client of a cluster, connecting and starting its informers
+// when it is used for the first time.
+func (c *Clusters) Get(clusterId uint) (*Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[clusterId]; ok {
+		return client, nil
+	}
+
+	var config *rest.Config
+	var err error
+
+	if clusterId == DefaultCluster {
+		config, err = c.config.RestConfig()
+	} else {
+		config, err = c.configFor(clusterId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterId != DefaultCluster {
+		c.config.tune(config)
+	}
+
+	client, err := NewClientForConfig(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("connected to cluster %d at %s", clusterId, config.Host)
+	stop := make(chan struct{})
+	c.clients[clusterId] = client
+	c.stops[clusterId] = stop
+
+	if c.connected != nil {
+		c.connected(clusterId, client, stop)
+	}
+
+	return client, nil
+}
+
+// Remove disconnects a cluster and stops its informers, so that changed
+// credentials are picked up on the next use.
+func (c *Clusters) Remove(clusterId uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if stop, ok := c.stops[clusterId]; ok {
+		close(stop)
+	}
+
+	delete(c.clients, clusterId)
+	delete(c.stops, clusterId)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestClusters(t *testing.T) {
+	requested := map[uint]int{}
+	connected := map[uint]chan struct{}{}
+	clusters := NewClusters(
+		&Config{QPS: 25, Burst: 50},
+		func(clusterId uint) (*rest.Config, error) {
+			requested[clusterId]++
+
+			if clusterId == 3 {
+				return nil, errors.New("unknown cluster")
+			}
+
+			return &rest.Config{Host: "https://remote.example.com"}, nil
+		},
+		func(clusterId uint, client *Client, stop chan struct{}) {
+			connected[clusterId] = stop
+		},
+	)
+	client, err := clusters.Get(2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.config.Host != "https://remote.example.com" || client.config.QPS != 25 || client.config.Burst != 50 {
+		t.Errorf("got config %+v", client.config)
+	}
+
+	again, err := clusters.Get(2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again != client || requested[2] != 1 || len(connected) != 1 {
+		t.Errorf("connected again, requested %v", requested)
+	}
+
+	stop := connected[2]
+	clusters.Remove(2)
+
+	select {
+	case <-stop:
+	default:
+		t.Fatal("removing the cluster did not stop its informers")
+	}
+
+	reconnected, err := clusters.Get(2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reconnected == client || requested[2] != 2 || connected[2] == stop {
+		t.Errorf("the removed cluster was not reconnected, requested %v", requested)
+	}
+
+	_, err = clusters.Get(3)
+
+	if err == nil || len(clusters.clients) != 1 {
+		t.Fatalf("connected an unknown cluster: %v", err)
+	}
+
+	// Removing a cluster which never connected does nothing.
+	clusters.Remove(3)
+}
+
+func TestClustersDefault(t *testing.T) {
+	clusters := NewClusters(&Config{Kubeconfig: "missing"}, func(clusterId uint) (*rest.Config, error) {
+		t.Fatalf("resolved the default cluster as cluster %d", clusterId)
+		return nil, nil
+	}, nil)
+
+	if _, err := clusters.Get(DefaultCluster); err == nil {
+		t.Fatal("connected without a kubeconfig")
+	}
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"bufio"
 	"context"
-	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -109,7 +108,7 @@ func (c *PodLoggingController) podUpdate(old, new interface{}) {
 
 	labels := pod.ObjectMeta.Labels
 	job_id := JobIdAsUint(labels["job_id"])
-	log.Infof("pod %s updated for job id %d with phase %s \n", job_id, pod.Name, string(pod.Status.Phase))
+	log.Infof("pod %s updated for job id %d with phase %s \n", pod.Name, job_id, string(pod.Status.Phase))
 	c.updateJobStatus(job_id, string(pod.Status.Phase))
 	_, ok := c.pods[pod.Name]
 
@@ -187,8 +186,7 @@ func NewInformer(clientset *Clientset, jobService *job.JobService) *Informer {
 	}
 }
 
-func (s *Informer) StartInformer() {
-	flag.Parse()
+func (s *Informer) StartInformer(stop chan struct{}) {
 	logs.InitLogs()
 	defer logs.FlushLogs()
 
@@ -203,13 +201,10 @@ func (s *Informer) StartInformer() {
 		informers.WithNamespace(""),
 		labelOptions)
 	controller := NewPodLoggingController(factory, s.jobService, s.clientset)
-	stop := make(chan struct{})
-
-	defer close(stop)
 
 	err := controller.Run(stop)
 	if err != nil {
 		klog.Fatal(err)
 	}
-	select {}
+	<-stop
 }
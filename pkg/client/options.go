@@ -0,0 +1,468 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// OPTIONS_EXTENSION is the schema keyword declaring where the options of
+	// a form field come from.
+	OPTIONS_EXTENSION = "x-selfservice-options"
+	// MAX_OPTIONS_RESPONSE_SIZE limits the documents of HTTP option sources.
+	MAX_OPTIONS_RESPONSE_SIZE = 4 << 20
+)
+
+var fieldReference = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// OptionSource lists the options of a form field from the Kubernetes API or
+// an HTTP endpoint returning JSON. Value and Label are JSONPath expressions
+// evaluated against each item. Strings may reference the values of other
+// fields as {{field}}, the options then depend on those fields.
+type OptionSource struct {
+	Kubernetes *KubernetesOptions `json:"kubernetes,omitempty"`
+	Http       *HttpOptions       `json:"http,omitempty"`
+	Value      string             `json:"value,omitempty"`
+	Label      string             `json:"label,omitempty"`
+	DependsOn  []string           `json:"dependsOn,omitempty"`
+	Ttl        int                `json:"ttl,omitempty"`
+}
+
+type KubernetesOptions struct {
+	Group         string `json:"group,omitempty"`
+	Version       string `json:"version"`
+	Resource      string `json:"resource"`
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// HttpOptions fetches a JSON document, Items selecting the list of items in
+// it. The document itself is the list when Items is empty.
+type HttpOptions struct {
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Items   string            `json:"items,omitempty"`
+}
+
+type Option struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// OptionsCache keeps the options of fields until they expire. Once it holds
+// size entries, the entry expiring first makes room for a new one.
+type OptionsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]optionsEntry
+}
+
+type optionsEntry struct {
+	options []Option
+	expires time.Time
+}
+
+func NewOptionsCache(ttl time.Duration, size int) *OptionsCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &OptionsCache{ttl: ttl, size: size, entries: map[string]optionsEntry{}}
+}
+
+func (c *OptionsCache) Get(key string) ([]Option, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.options, true
+}
+
+// Put caches the options of a key for ttl, or the default ttl of the cache
+// when it is not positive.
+func (c *OptionsCache) Put(key string, options []Option, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		first := ""
+
+		for k, entry := range c.entries {
+			if first == "" || entry.expires.Before(c.entries[first].expires) {
+				first = k
+			}
+		}
+
+		delete(c.entries, first)
+	}
+
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
+	c.entries[key] = optionsEntry{options: options, expires: now.Add(ttl)}
+}
+
+// ParseOptionSource decodes and validates the x-selfservice-options of a
+// field schema.
+func ParseOptionSource(extension interface{}) (*OptionSource, error) {
+	data, err := json.Marshal(extension)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var source OptionSource
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&source)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", OPTIONS_EXTENSION)
+	}
+
+	if (source.Kubernetes == nil) == (source.Http == nil) {
+		return nil, errors.Errorf("%s requires either kubernetes or http", OPTIONS_EXTENSION)
+	}
+
+	if source.Kubernetes != nil && (source.Kubernetes.Version == "" || source.Kubernetes.Resource == "") {
+		return nil, errors.Errorf("%s kubernetes requires version and resource", OPTIONS_EXTENSION)
+	}
+
+	if source.Http != nil && source.Http.Url == "" {
+		return nil, errors.Errorf("%s http requires url", OPTIONS_EXTENSION)
+	}
+
+	for _, expression := range []string{source.Value, source.Label, source.itemsExpression()} {
+		if expression == "" {
+			continue
+		}
+
+		_, err = parseExpression(expression)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s has an invalid expression %q", OPTIONS_EXTENSION, expression)
+		}
+	}
+
+	return &source, nil
+}
+
+func (o *OptionSource) itemsExpression() string {
+	if o.Http == nil {
+		return ""
+	}
+
+	return o.Http.Items
+}
+
+// Dependencies lists the fields the options depend on, declared or
+// referenced.
+func (o *OptionSource) Dependencies() []string {
+	dependencies := append([]string{}, o.DependsOn...)
+	var templates []string
+
+	if o.Kubernetes != nil {
+		templates = append(templates, o.Kubernetes.Namespace, o.Kubernetes.LabelSelector)
+	}
+
+	if o.Http != nil {
+		templates = append(templates, o.Http.Url)
+
+		for _, value := range o.Http.Headers {
+			templates = append(templates, value)
+		}
+	}
+
+	for _, template := range templates {
+		for _, match := range fieldReference.FindAllStringSubmatch(template, -1) {
+			if !Contains(dependencies, match[1]) {
+				dependencies = append(dependencies, match[1])
+			}
+		}
+	}
+
+	return dependencies
+}
+
+// Render substitutes the values of the fields the options depend on, all of
+// which must be set. Values are escaped in URLs, and must be label values in
+// label selectors so they cannot select on other keys. Rendered namespaces
+// must be DNS-1123 labels.
+func (o *OptionSource) Render(values map[string]string) (*OptionSource, error) {
+	for _, dependency := range o.Dependencies() {
+		if values[dependency] == "" {
+			return nil, errors.Errorf("options depend on field %s, which is not set", dependency)
+		}
+	}
+
+	substitute := func(template string, escape func(string) string) string {
+		return fieldReference.ReplaceAllStringFunc(template, func(reference string) string {
+			return escape(values[fieldReference.FindStringSubmatch(reference)[1]])
+		})
+	}
+	identity := func(value string) string { return value }
+	rendered := *o
+
+	if o.Kubernetes != nil {
+		kubernetes := *o.Kubernetes
+		kubernetes.Namespace = substitute(kubernetes.Namespace, identity)
+
+		if kubernetes.Namespace != "" {
+			if messages := validation.IsDNS1123Label(kubernetes.Namespace); len(messages) > 0 {
+				return nil, errors.Errorf("options namespace %q is invalid: %s", kubernetes.Namespace, strings.Join(messages, "; "))
+			}
+		}
+
+		for _, match := range fieldReference.FindAllStringSubmatch(kubernetes.LabelSelector, -1) {
+			if messages := validation.IsValidLabelValue(values[match[1]]); len(messages) > 0 {
+				return nil, errors.Errorf("field %s is not a valid label value: %s", match[1], strings.Join(messages, "; "))
+			}
+		}
+
+		kubernetes.LabelSelector = substitute(kubernetes.LabelSelector, identity)
+
+		if _, err := labels.Parse(kubernetes.LabelSelector); err != nil {
+			return nil, errors.Wrap(err, "invalid options label selector")
+		}
+
+		rendered.Kubernetes = &kubernetes
+	}
+
+	if o.Http != nil {
+		httpOptions := *o.Http
+		httpOptions.Url = substitute(httpOptions.Url, url.QueryEscape)
+		httpOptions.Headers = map[string]string{}
+
+		for key, value := range o.Http.Headers {
+			httpOptions.Headers[key] = substitute(value, identity)
+		}
+
+		rendered.Http = &httpOptions
+	}
+
+	return &rendered, nil
+}
+
+// ListOptions lists the options of a rendered Kubernetes source, as the
+// impersonated user when set.
+func (c *Client) ListOptions(ctx context.Context, source *OptionSource, impersonate *rest.ImpersonationConfig) ([]Option, error) {
+	kube, err := c.impersonating(impersonate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	k := source.Kubernetes
+	gvr := schema.GroupVersionResource{Group: k.Group, Version: k.Version, Resource: k.Resource}
+	list, err := kube.Dynamic().Resource(gvr).Namespace(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: k.LabelSelector})
+
+	if err != nil {
+		return nil, impersonationError(err, impersonate)
+	}
+
+	items := make([]interface{}, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		items = append(items, item.Object)
+	}
+
+	return source.options(items, "{.metadata.name}")
+}
+
+// FetchOptions fetches the options of a rendered HTTP source, whose host must
+// be one of allowedHosts.
+func FetchOptions(ctx context.Context, source *OptionSource, allowedHosts []string) ([]Option, error) {
+	endpoint, err := url.Parse(source.Http.Url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, errors.Errorf("options url %s is not http or https", source.Http.Url)
+	}
+
+	if !Contains(allowedHosts, endpoint.Hostname()) {
+		return nil, errors.Errorf("options host %s is not allowed", endpoint.Hostname())
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	for key, value := range source.Http.Headers {
+		request.Header.Set(key, value)
+	}
+
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(redirect *http.Request, via []*http.Request) error {
+			if len(via) >= 5 || !Contains(allowedHosts, redirect.URL.Hostname()) {
+				return errors.Errorf("options url %s redirected to %s", endpoint.Redacted(), redirect.URL.Hostname())
+			}
+
+			return nil
+		},
+	}
+	response, err := httpClient.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("options url %s returned %s", endpoint.Redacted(), response.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, MAX_OPTIONS_RESPONSE_SIZE+1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) > MAX_OPTIONS_RESPONSE_SIZE {
+		return nil, errors.Errorf("options url %s returned more than %d bytes", endpoint.Redacted(), MAX_OPTIONS_RESPONSE_SIZE)
+	}
+
+	var document interface{}
+	err = json.Unmarshal(body, &document)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "options url %s did not return JSON", endpoint.Redacted())
+	}
+
+	items, ok := document.([]interface{})
+
+	if source.Http.Items != "" {
+		items, err = findAll(source.Http.Items, document)
+
+		if err != nil {
+			return nil, err
+		}
+	} else if !ok {
+		return nil, errors.Errorf("options url %s did not return a list, set items to select it", endpoint.Redacted())
+	}
+
+	return source.options(items, "{@}")
+}
+
+func (o *OptionSource) options(items []interface{}, defaultValue string) ([]Option, error) {
+	valueExpression := o.Value
+
+	if valueExpression == "" {
+		valueExpression = defaultValue
+	}
+
+	options := []Option{}
+
+	for _, item := range items {
+		value, err := evaluate(valueExpression, item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		label := value
+
+		if o.Label != "" {
+			label, err = evaluate(o.Label, item)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options = append(options, Option{Value: value, Label: label})
+	}
+
+	return options, nil
+}
+
+func parseExpression(expression string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = fmt.Sprintf("{%s}", expression)
+	}
+
+	parser := jsonpath.New("options").AllowMissingKeys(true)
+	err := parser.Parse(expression)
+	return parser, err
+}
+
+func evaluate(expression string, item interface{}) (string, error) {
+	parser, err := parseExpression(expression)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = parser.Execute(&buf, item)
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func findAll(expression string, document interface{}) ([]interface{}, error) {
+	parser, err := parseExpression(expression)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := parser.FindResults(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+
+	for _, result := range results {
+		for _, value := range result {
+			items = append(items, value.Interface())
+		}
+	}
+
+	return items, nil
+}
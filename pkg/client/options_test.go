@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func optionSource(t *testing.T, extension string) *OptionSource {
+	t.Helper()
+	var decoded interface{}
+
+	if err := json.Unmarshal([]byte(extension), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := ParseOptionSource(decoded)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return source
+}
+
+func TestParseOptionSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		extension string
+		valid     bool
+	}{
+		{name: "kubernetes", extension: `{"kubernetes": {"version": "v1", "resource": "configmaps", "namespace": "{{namespace}}"}, "label": "{.metadata.labels.title}"}`, valid: true},
+		{name: "http", extension: `{"http": {"url": "https://options.local/sizes", "items": "$.items[*]"}, "value": "id", "ttl": 30}`, valid: true},
+		{name: "both", extension: `{"kubernetes": {"version": "v1", "resource": "configmaps"}, "http": {"url": "https://options.local"}}`},
+		{name: "neither", extension: `{"value": "id"}`},
+		{name: "kubernetes without resource", extension: `{"kubernetes": {"version": "v1"}}`},
+		{name: "http without url", extension: `{"http": {"items": "items"}}`},
+		{name: "unknown field", extension: `{"http": {"url": "https://options.local"}, "values": "id"}`},
+		{name: "invalid expression", extension: `{"http": {"url": "https://options.local"}, "value": "{.items[}"}`},
+		{name: "not an object", extension: `"https://options.local"`},
+	}
+
+	for _, test := range tests {
+		var extension interface{}
+
+		if err := json.Unmarshal([]byte(test.extension), &extension); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		_, err := ParseOptionSource(extension)
+
+		if test.valid && err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("%s: parsed", test.name)
+		}
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	source := optionSource(t, `{"http": {"url": "https://options.local/{{region}}/{{ zone }}", "headers": {"X-Team": "{{team}}"}}, "dependsOn": ["region", "size"]}`)
+	dependencies := source.Dependencies()
+	want := []string{"region", "size", "zone", "team"}
+
+	if !reflect.DeepEqual(dependencies, want) {
+		t.Errorf("got %v, want %v", dependencies, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	source := optionSource(t, `{"http": {"url": "https://options.local/sizes?region={{region}}", "headers": {"X-Region": "{{region}}"}}}`)
+	rendered, err := source.Render(map[string]string{"region": "eu west&x=1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rendered.Http.Url != "https://options.local/sizes?region=eu+west%26x%3D1" || rendered.Http.Headers["X-Region"] != "eu west&x=1" {
+		t.Errorf("got %+v", rendered.Http)
+	}
+
+	if source.Http.Url != "https://options.local/sizes?region={{region}}" {
+		t.Error("rendering changed the source")
+	}
+
+	if _, err := source.Render(map[string]string{}); err == nil || !strings.Contains(err.Error(), "region") {
+		t.Errorf("got %v for a missing dependency", err)
+	}
+
+	kubernetes := optionSource(t, `{"kubernetes": {"version": "v1", "resource": "configmaps", "namespace": "{{namespace}}", "labelSelector": "team={{team}}"}}`)
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		valid  bool
+	}{
+		{name: "valid", values: map[string]string{"namespace": "tools", "team": "platform"}, valid: true},
+		{name: "selector injection", values: map[string]string{"namespace": "tools", "team": "platform,tier!=x"}},
+		{name: "invalid namespace", values: map[string]string{"namespace": "../tools", "team": "platform"}},
+	}
+
+	for _, test := range tests {
+		rendered, err := kubernetes.Render(test.values)
+
+		if test.valid && (err != nil || rendered.Kubernetes.Namespace != "tools" || rendered.Kubernetes.LabelSelector != "team=platform") {
+			t.Errorf("%s: got %+v, %v", test.name, rendered, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("%s: rendered %+v", test.name, rendered.Kubernetes)
+		}
+	}
+}
+
+func optionsServer(t *testing.T) (*httptest.Server, []string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list":
+			io.WriteString(rw, `["small", "large"]`)
+		case "/items":
+			if r.Header.Get("X-Team") != "platform" {
+				http.Error(rw, "no team", http.StatusForbidden)
+				return
+			}
+
+			io.WriteString(rw, `{"items": [{"id": "s", "title": "Small"}, {"id": "l", "title": "Large"}]}`)
+		case "/object":
+			io.WriteString(rw, `{"id": "s"}`)
+		case "/text":
+			io.WriteString(rw, `small`)
+		case "/large":
+			io.WriteString(rw, "["+strings.Repeat(`"x",`, MAX_OPTIONS_RESPONSE_SIZE/4)+`"x"]`)
+		case "/redirect":
+			http.Redirect(rw, r, "http://localhost/list", http.StatusFound)
+		default:
+			http.NotFound(rw, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, []string{"127.0.0.1"}
+}
+
+func TestFetchOptions(t *testing.T) {
+	server, allowedHosts := optionsServer(t)
+
+	tests := []struct {
+		name      string
+		extension string
+		want      []Option
+	}{
+		{name: "list", extension: `{"http": {"url": "` + server.URL + `/list"}}`, want: []Option{{"small", "small"}, {"large", "large"}}},
+		{name: "items", extension: `{"http": {"url": "` + server.URL + `/items", "items": "{.items[*]}", "headers": {"X-Team": "platform"}}, "value": "{.id}", "label": "{.title}"}`, want: []Option{{"s", "Small"}, {"l", "Large"}}},
+	}
+
+	for _, test := range tests {
+		options, err := FetchOptions(context.Background(), optionSource(t, test.extension), allowedHosts)
+
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(options, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, options, test.want)
+		}
+	}
+}
+
+func TestFetchOptionsErrors(t *testing.T) {
+	server, allowedHosts := optionsServer(t)
+
+	tests := map[string]string{
+		"host not allowed": `{"http": {"url": "http://localhost/list"}}`,
+		"not http":         `{"http": {"url": "file:///etc/passwd"}}`,
+		"status":           `{"http": {"url": "` + server.URL + `/items", "items": "{.items[*]}"}}`,
+		"not a list":       `{"http": {"url": "` + server.URL + `/object"}}`,
+		"not json":         `{"http": {"url": "` + server.URL + `/text"}}`,
+		"too large":        `{"http": {"url": "` + server.URL + `/large"}}`,
+		"redirect":         `{"http": {"url": "` + server.URL + `/redirect"}}`,
+	}
+
+	for name, extension := range tests {
+		if options, err := FetchOptions(context.Background(), optionSource(t, extension), allowedHosts); err == nil {
+			t.Errorf("%s: got options %v", name, options)
+		}
+	}
+}
+
+func TestOptionsCache(t *testing.T) {
+	cache := NewOptionsCache(time.Hour, 2)
+	small := []Option{{"s", "Small"}}
+
+	if _, ok := cache.Get("sizes"); ok {
+		t.Fatal("got options of an empty cache")
+	}
+
+	cache.Put("sizes", small, 0)
+
+	if options, ok := cache.Get("sizes"); !ok || !reflect.DeepEqual(options, small) {
+		t.Fatalf("got %v, %t", options, ok)
+	}
+
+	cache.Put("expiring", small, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("expiring"); ok {
+		t.Error("got expired options")
+	}
+
+	// The expired entry is evicted rather than a live one.
+	cache.Put("regions", small, time.Minute)
+
+	if len(cache.entries) != 2 {
+		t.Errorf("cache holds %d entries", len(cache.entries))
+	}
+
+	// Full, the entry expiring first is evicted.
+	cache.Put("zones", small, 0)
+
+	if _, ok := cache.Get("regions"); ok {
+		t.Error("kept the entry expiring first")
+	}
+
+	for _, key := range []string{"sizes", "zones"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("evicted %s", key)
+		}
+	}
+
+	// Replacing an entry of a full cache keeps the others.
+	cache.Put("zones", small, time.Minute)
+
+	if _, ok := cache.Get("sizes"); !ok || len(cache.entries) != 2 {
+		t.Error("replacing an entry evicted another")
+	}
+}
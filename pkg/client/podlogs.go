@@ -8,7 +8,10 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 func init() {
@@ -17,12 +20,8 @@ func init() {
 
 type Client struct {
 	*kubernetes.Clientset
-}
-
-func NewClient() *Client {
-	return &Client{
-		connect(),
-	}
+	dynamic dynamic.Interface
+	config  *rest.Config
 }
 
 func Contains(arr []string, str string) bool {
@@ -74,11 +73,44 @@ func (c *Client) StreamPodLogs(ctx context.Context, lines chan string, stop chan
 	}
 }
 
+// Run creates the batch Job of a job, as the impersonated user when the
+// config carries one.
 func (c *Client) Run(jobName string, jobConfig JobConfig) (*batchv1.Job, error) {
-	jobs := c.BatchV1().Jobs(jobConfig.ObjectMeta.Namespace)
+	kube, err := c.impersonating(jobConfig.Impersonate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := kube.BatchV1().Jobs(jobConfig.ObjectMeta.Namespace)
 	jobSpec := genereateJobSpec(jobName, jobConfig)
 	resp, err := jobs.Create(context.TODO(), jobSpec, metav1.CreateOptions{})
-	return resp, err
+	return resp, impersonationError(err, jobConfig.Impersonate)
+}
+
+// RunResource creates the primary object of an application which declares a
+// status check instead of running a batch Job.
+func (c *Client) RunResource(jobName string, check *StatusCheck, resourceConfig ResourceConfig) (*unstructured.Unstructured, error) {
+	kube, err := c.impersonating(resourceConfig.Impersonate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := kube.dynamic.Resource(check.GroupVersionResource()).Namespace(resourceConfig.ObjectMeta.Namespace)
+	resource := generateResource(jobName, check, resourceConfig)
+	resp, err := resources.Create(context.TODO(), resource, metav1.CreateOptions{})
+	return resp, impersonationError(err, resourceConfig.Impersonate)
+}
+
+func (c *Client) impersonating(impersonate *rest.ImpersonationConfig) (*Client, error) {
+	if impersonate == nil {
+		return c, nil
+	}
+
+	config := rest.CopyConfig(c.config)
+	config.Impersonate = *impersonate
+	return NewClientForConfig(config)
 }
 
 func (c *Client) GetJobStatus(jobName string, namespace string) (*batchv1.JobStatus, error) {
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func apiServer(t *testing.T, status int, headers *http.Header) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		*headers = r.Header.Clone()
+		body, _ := io.ReadAll(r.Body)
+		rw.Header().Set("Content-Type", "application/json")
+
+		if status != http.StatusCreated {
+			rw.WriteHeader(status)
+			io.WriteString(rw, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden", "code": 403, "message": "jobs.batch is forbidden"}`)
+			return
+		}
+
+		rw.WriteHeader(status)
+		rw.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	kube, err := NewClientForConfig(&rest.Config{Host: server.URL})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return kube
+}
+
+func testJobConfig(impersonate *rest.ImpersonationConfig) JobConfig {
+	jobConfig := JobConfig{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "tools"}, Impersonate: impersonate}
+	jobConfig.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	jobConfig.Spec.Template.Spec.Containers = []corev1.Container{{Name: "main", Image: "busybox"}}
+	return jobConfig
+}
+
+func TestRunImpersonates(t *testing.T) {
+	var headers http.Header
+	kube := apiServer(t, http.StatusCreated, &headers)
+	impersonate := &rest.ImpersonationConfig{UserName: "jane", Groups: []string{"dev", "ops"}}
+	resp, err := kube.Run("backup-abc", testJobConfig(impersonate))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Name != "backup-abc" {
+		t.Errorf("got job %s", resp.Name)
+	}
+
+	if headers.Get("Impersonate-User") != "jane" || !reflect.DeepEqual(headers.Values("Impersonate-Group"), []string{"dev", "ops"}) {
+		t.Errorf("got headers %v", headers)
+	}
+
+	if _, err := kube.Run("backup-def", testJobConfig(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if headers.Get("Impersonate-User") != "" {
+		t.Errorf("impersonated without a user: %v", headers)
+	}
+}
+
+func TestRunDenied(t *testing.T) {
+	var headers http.Header
+	kube := apiServer(t, http.StatusForbidden, &headers)
+	_, err := kube.Run("backup-abc", testJobConfig(&rest.ImpersonationConfig{UserName: "jane"}))
+	var forbidden *ForbiddenError
+
+	if !errors.As(err, &forbidden) || forbidden.User != "jane" {
+		t.Fatalf("got error %v", err)
+	}
+
+	// Without impersonation the server itself was denied.
+	_, err = kube.Run("backup-abc", testJobConfig(nil))
+
+	if err == nil || errors.As(err, &forbidden) {
+		t.Fatalf("got error %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// NAME_SUFFIX_LENGTH is the length of the random suffix appended to the name
+// of the objects created for jobs.
+const NAME_SUFFIX_LENGTH = 10
+
+// JobViolations lists the policy rules broken by the job of an application.
+// Jobs breaking any rule are refused before reaching the cluster.
+func JobViolations(jobConfig JobConfig) []string {
+	violations := metaViolations(jobConfig.ObjectMeta)
+	podSpec := jobConfig.Spec.Template.Spec
+
+	if len(podSpec.Containers) == 0 {
+		violations = append(violations, "spec.template.spec.containers must not be empty")
+	}
+
+	for i, container := range podSpec.Containers {
+		if container.Name == "" {
+			violations = append(violations, fmt.Sprintf("spec.template.spec.containers[%d].name is required", i))
+		}
+
+		if container.Image == "" {
+			violations = append(violations, fmt.Sprintf("spec.template.spec.containers[%d].image is required", i))
+		}
+	}
+
+	if podSpec.RestartPolicy != corev1.RestartPolicyNever && podSpec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		violations = append(violations, "spec.template.spec.restartPolicy must be Never or OnFailure")
+	}
+
+	return violations
+}
+
+// ResourceViolations lists the policy rules broken by the resource of an
+// application with a status check.
+func ResourceViolations(resourceConfig ResourceConfig) []string {
+	violations := metaViolations(resourceConfig.ObjectMeta)
+
+	if len(resourceConfig.Spec) == 0 {
+		violations = append(violations, "spec must not be empty")
+	}
+
+	return violations
+}
+
+func metaViolations(meta metav1.ObjectMeta) []string {
+	var violations []string
+
+	if meta.Name == "" {
+		violations = append(violations, "metadata.name is required")
+	} else {
+		suffixed := meta.Name + "-" + strings.Repeat("a", NAME_SUFFIX_LENGTH)
+
+		for _, message := range validation.IsDNS1123Label(suffixed) {
+			violations = append(violations, fmt.Sprintf("metadata.name %q with its random suffix: %s", meta.Name, message))
+		}
+	}
+
+	if meta.Namespace != "" {
+		for _, message := range validation.IsDNS1123Label(meta.Namespace) {
+			violations = append(violations, fmt.Sprintf("metadata.namespace %q: %s", meta.Namespace, message))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJobViolations(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []string
+	}{
+		{
+			name:    "valid",
+			payload: `{"metadata": {"name": "backup", "namespace": "tools"}, "spec": {"template": {"spec": {"restartPolicy": "Never", "containers": [{"name": "main", "image": "busybox"}]}}}}`,
+		},
+		{
+			name:    "on failure",
+			payload: `{"metadata": {"name": "backup"}, "spec": {"template": {"spec": {"restartPolicy": "OnFailure", "containers": [{"name": "main", "image": "busybox"}]}}}}`,
+		},
+		{
+			// Previously accepted by the server and refused by the cluster.
+			name:    "no restart policy",
+			payload: `{"metadata": {"name": "backup"}, "spec": {"template": {"spec": {"containers": [{"name": "main", "image": "busybox"}]}}}}`,
+			want:    []string{"spec.template.spec.restartPolicy must be Never or OnFailure"},
+		},
+		{
+			name:    "restart always",
+			payload: `{"metadata": {"name": "backup"}, "spec": {"template": {"spec": {"restartPolicy": "Always", "containers": [{"name": "main", "image": "busybox"}]}}}}`,
+			want:    []string{"spec.template.spec.restartPolicy must be Never or OnFailure"},
+		},
+		{
+			name:    "no containers",
+			payload: `{"metadata": {"name": "backup"}, "spec": {"template": {"spec": {"restartPolicy": "Never"}}}}`,
+			want:    []string{"spec.template.spec.containers must not be empty"},
+		},
+		{
+			name:    "incomplete container",
+			payload: `{"metadata": {"name": "backup"}, "spec": {"template": {"spec": {"restartPolicy": "Never", "containers": [{"name": "main", "image": "busybox"}, {}]}}}}`,
+			want:    []string{"spec.template.spec.containers[1].name is required", "spec.template.spec.containers[1].image is required"},
+		},
+		{
+			name:    "no name",
+			payload: `{"metadata": {}, "spec": {"template": {"spec": {"restartPolicy": "Never", "containers": [{"name": "main", "image": "busybox"}]}}}}`,
+			want:    []string{"metadata.name is required"},
+		},
+	}
+
+	for _, test := range tests {
+		var jobConfig JobConfig
+
+		if err := json.Unmarshal([]byte(test.payload), &jobConfig); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		if got := JobViolations(jobConfig); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestMetaViolations(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		count    int
+	}{
+		{name: "valid", metadata: `{"name": "backup-2", "namespace": "tools"}`},
+		{name: "upper case", metadata: `{"name": "Backup"}`, count: 1},
+		{name: "too long with the suffix", metadata: `{"name": "a-name-of-fifty-five-characters-which-is-too-long-so-far"}`, count: 1},
+		{name: "bad namespace", metadata: `{"name": "backup", "namespace": "tools_prod"}`, count: 1},
+	}
+
+	for _, test := range tests {
+		var resourceConfig ResourceConfig
+
+		if err := json.Unmarshal([]byte(`{"metadata": `+test.metadata+`, "spec": {"replicas": 1}}`), &resourceConfig); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		if got := ResourceViolations(resourceConfig); len(got) != test.count {
+			t.Errorf("%s: got %q, want %d violations", test.name, got, test.count)
+		}
+	}
+}
+
+func TestResourceViolations(t *testing.T) {
+	var resourceConfig ResourceConfig
+
+	if err := json.Unmarshal([]byte(`{"metadata": {"name": "cache"}}`), &resourceConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"spec must not be empty"}
+
+	if got := ResourceViolations(resourceConfig); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
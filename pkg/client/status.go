@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/infor-design/selfservice/pkg/application"
+	"github.com/infor-design/selfservice/pkg/job"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var phases = []string{job.PhaseFailed, job.PhaseSucceeded, job.PhaseRunning, job.PhasePending}
+
+// ParseStatusCheck decodes and validates the status check of an application.
+// A nil check is returned when the application does not declare one.
+func ParseStatusCheck(data datatypes.JSON) (*StatusCheck, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var check StatusCheck
+	err := json.Unmarshal(data, &check)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if check.Version == "" || check.Resource == "" || check.Kind == "" || check.Expression == "" {
+		return nil, errors.New("status_check requires version, resource, kind and expression")
+	}
+
+	for phase := range check.Phases {
+		if !Contains(phases, phase) {
+			return nil, errors.Errorf("status_check has unknown phase %q", phase)
+		}
+	}
+
+	_, err = check.parser()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "status_check has an invalid expression")
+	}
+
+	return &check, nil
+}
+
+func (c *StatusCheck) GroupVersion() schema.GroupVersion {
+	return schema.GroupVersion{Group: c.Group, Version: c.Version}
+}
+
+func (c *StatusCheck) GroupVersionResource() schema.GroupVersionResource {
+	return c.GroupVersion().WithResource(c.Resource)
+}
+
+func (c *StatusCheck) parser() (*jsonpath.JSONPath, error) {
+	expression := c.Expression
+
+	if !strings.HasPrefix(expression, "{") {
+		expression = fmt.Sprintf("{%s}", expression)
+	}
+
+	parser := jsonpath.New("status").AllowMissingKeys(true)
+	err := parser.Parse(expression)
+	return parser, err
+}
+
+// Evaluate runs the expression against the object and maps the result to a
+// selfservice phase. An empty phase is returned when the value is unknown.
+func (c *StatusCheck) Evaluate(resource *unstructured.Unstructured) (string, error) {
+	parser, err := c.parser()
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = parser.Execute(&buf, resource.Object)
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.phase(strings.TrimSpace(buf.String())), nil
+}
+
+func (c *StatusCheck) phase(value string) string {
+	for _, phase := range phases {
+		if Contains(c.Phases[phase], value) {
+			return phase
+		}
+	}
+
+	if Contains(phases, value) {
+		return value
+	}
+
+	if value == "" {
+		return job.PhasePending
+	}
+
+	return ""
+}
+
+func NewStatusInformer(dynamicClient dynamic.Interface, clusterId uint, jobService *job.JobService, applicationService *application.Service) *StatusInformer {
+	labelOptions := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = "invoked="
+	}
+
+	return &StatusInformer{
+		factory:            dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, 3*time.Minute, "", labelOptions),
+		clusterId:          clusterId,
+		jobService:         jobService,
+		applicationService: applicationService,
+		watched:            map[schema.GroupVersionResource]bool{},
+	}
+}
+
+// StartInformer watches the resources referenced by the status checks of the
+// applications bound to the cluster, picking up newly declared resources
+// every minute.
+func (s *StatusInformer) StartInformer(stop chan struct{}) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		s.watchApplications(stop)
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *StatusInformer) watchApplications(stop chan struct{}) {
+	for _, app := range s.applicationService.ListByCluster(s.clusterId) {
+		check, err := ParseStatusCheck(app.StatusCheck)
+
+		if err != nil {
+			log.Errorf("application %d: %s", app.ID, err)
+			continue
+		}
+
+		if check == nil {
+			continue
+		}
+
+		gvr := check.GroupVersionResource()
+
+		if s.watched[gvr] {
+			continue
+		}
+
+		log.Infof("watching %s for application status", gvr.String())
+		s.factory.ForResource(gvr).Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    s.resourceAdd,
+				UpdateFunc: s.resourceUpdate,
+			},
+		)
+		s.watched[gvr] = true
+	}
+
+	s.factory.Start(stop)
+}
+
+func (s *StatusInformer) resourceAdd(obj interface{}) {
+	s.updateJobStatus(obj)
+}
+
+func (s *StatusInformer) resourceUpdate(old, new interface{}) {
+	s.updateJobStatus(new)
+}
+
+func (s *StatusInformer) updateJobStatus(obj interface{}) {
+	resource, ok := obj.(*unstructured.Unstructured)
+
+	if !ok {
+		return
+	}
+
+	jobId := JobIdAsUint(resource.GetLabels()["job_id"])
+	job, err := s.jobService.Get(jobId)
+
+	if err != nil {
+		return
+	}
+
+	app, err := s.applicationService.Get(job.ApplicationID)
+
+	if err != nil {
+		return
+	}
+
+	check, err := ParseStatusCheck(app.StatusCheck)
+
+	if err != nil || check == nil {
+		return
+	}
+
+	if resource.GroupVersionKind() != check.GroupVersion().WithKind(check.Kind) {
+		return
+	}
+
+	phase, err := check.Evaluate(resource)
+
+	if err != nil {
+		log.Errorf("%s %s: %s", resource.GetKind(), resource.GetName(), err)
+		return
+	}
+
+	if phase == "" || phase == job.Phase {
+		return
+	}
+
+	log.Infof("%s %s updated for job id %d with phase %s", resource.GetKind(), resource.GetName(), jobId, phase)
+	job.Phase = phase
+	s.jobService.Update(job)
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/infor-design/selfservice/pkg/job"
+	"gorm.io/datatypes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseStatusCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "not declared"},
+		{name: "null", data: "null"},
+		{name: "valid", data: `{"group": "batch", "version": "v1", "resource": "jobs", "kind": "Job", "expression": ".status.phase"}`},
+		{name: "braced expression", data: `{"version": "v1", "resource": "pods", "kind": "Pod", "expression": "{.status.phase}"}`},
+		{name: "phases", data: `{"version": "v1", "resource": "pods", "kind": "Pod", "expression": ".status.phase", "phases": {"Succeeded": ["Done"]}}`},
+		{name: "invalid json", data: `{"version"`, err: "unexpected end of JSON input"},
+		{name: "missing fields", data: `{"version": "v1", "resource": "pods"}`, err: "status_check requires version, resource, kind and expression"},
+		{name: "unknown phase", data: `{"version": "v1", "resource": "pods", "kind": "Pod", "expression": ".status.phase", "phases": {"Done": ["Done"]}}`, err: `status_check has unknown phase "Done"`},
+		{name: "invalid expression", data: `{"version": "v1", "resource": "pods", "kind": "Pod", "expression": ".status[phase"}`, err: "status_check has an invalid expression"},
+	}
+
+	for _, test := range tests {
+		check, err := ParseStatusCheck(datatypes.JSON(test.data))
+
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s: %s", test.name, err)
+			}
+
+			if (check == nil) != (test.data == "" || test.data == "null") {
+				t.Errorf("%s: got check %+v", test.name, check)
+			}
+
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %v, want %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestStatusCheckGroupVersionResource(t *testing.T) {
+	check := StatusCheck{Group: "batch", Version: "v1", Resource: "jobs", Kind: "Job"}
+	want := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+
+	if check.GroupVersionResource() != want {
+		t.Errorf("got %v, want %v", check.GroupVersionResource(), want)
+	}
+
+	core := StatusCheck{Version: "v1", Resource: "pods"}
+
+	if core.GroupVersion().String() != "v1" {
+		t.Errorf("got %s for a core resource", core.GroupVersion())
+	}
+}
+
+func TestStatusCheckEvaluate(t *testing.T) {
+	check := StatusCheck{
+		Version:    "v1",
+		Resource:   "widgets",
+		Kind:       "Widget",
+		Expression: ".status.state",
+		Phases: map[string][]string{
+			job.PhaseSucceeded: {"Ready", "Done"},
+			job.PhaseFailed:    {"Error"},
+			job.PhaseRunning:   {"Progressing"},
+		},
+	}
+	tests := map[string]string{
+		"Ready":       job.PhaseSucceeded,
+		"Done":        job.PhaseSucceeded,
+		"Error":       job.PhaseFailed,
+		"Progressing": job.PhaseRunning,
+		// Values naming a phase map to it unless mapped otherwise.
+		"Pending": job.PhasePending,
+		"Failed":  job.PhaseFailed,
+		// A missing value is pending, an unknown one has no phase.
+		"":        job.PhasePending,
+		"Unknown": "",
+	}
+
+	for value, want := range tests {
+		object := map[string]interface{}{"kind": "Widget"}
+
+		if value != "" {
+			object["status"] = map[string]interface{}{"state": value}
+		}
+
+		phase, err := check.Evaluate(&unstructured.Unstructured{Object: object})
+
+		if err != nil {
+			t.Fatalf("%q: %s", value, err)
+		}
+
+		if phase != want {
+			t.Errorf("%q: got phase %q, want %q", value, phase, want)
+		}
+	}
+}
+
+func TestStatusCheckEvaluateBraced(t *testing.T) {
+	check := StatusCheck{Expression: `{.status.conditions[?(@.type=="Complete")].status}`, Phases: map[string][]string{job.PhaseSucceeded: {"True"}}}
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Failed", "status": "False"},
+				map[string]interface{}{"type": "Complete", "status": "True"},
+			},
+		},
+	}}
+	phase, err := check.Evaluate(resource)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if phase != job.PhaseSucceeded {
+		t.Errorf("got phase %q", phase)
+	}
+}
@@ -1,12 +1,16 @@
 package client
 
 import (
+	"github.com/infor-design/selfservice/pkg/application"
 	"github.com/infor-design/selfservice/pkg/job"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/rest"
 )
 
 type Informer struct {
@@ -23,7 +27,47 @@ type PodLoggingController struct {
 }
 
 type JobConfig struct {
-	ObjectMeta metav1.ObjectMeta `json:"metadata"`
-	Spec       batchv1.JobSpec   `json:"spec"`
-	Labels     map[string]string `json:"labels"`
+	ObjectMeta   metav1.ObjectMeta         `json:"metadata"`
+	Spec         batchv1.JobSpec           `json:"spec"`
+	Labels       map[string]string         `json:"labels"`
+	Cluster      uint                      `json:"cluster"`
+	ManifestHash string                    `json:"manifest_hash,omitempty"`
+	Impersonate  *rest.ImpersonationConfig `json:"-"`
+}
+
+type ResourceConfig struct {
+	ObjectMeta   metav1.ObjectMeta         `json:"metadata"`
+	Spec         map[string]interface{}    `json:"spec"`
+	Labels       map[string]string         `json:"labels"`
+	Cluster      uint                      `json:"cluster"`
+	ManifestHash string                    `json:"manifest_hash,omitempty"`
+	Impersonate  *rest.ImpersonationConfig `json:"-"`
+}
+
+// ForbiddenError is returned when the impersonated user may not create the
+// object of a job.
+type ForbiddenError struct {
+	User string
+	Err  error
+}
+
+// StatusCheck declares how the phase of a job is derived from the primary
+// object of an application, e.g. a custom resource. Expression is a JSONPath
+// expression evaluated against the object, and Phases maps the selfservice
+// phases (Pending, Running, Succeeded, Failed) to the values it may produce.
+type StatusCheck struct {
+	Group      string              `json:"group"`
+	Version    string              `json:"version"`
+	Resource   string              `json:"resource"`
+	Kind       string              `json:"kind"`
+	Expression string              `json:"expression"`
+	Phases     map[string][]string `json:"phases"`
+}
+
+type StatusInformer struct {
+	factory            dynamicinformer.DynamicSharedInformerFactory
+	clusterId          uint
+	jobService         *job.JobService
+	applicationService *application.Service
+	watched            map[schema.GroupVersionResource]bool
 }
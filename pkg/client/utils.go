@@ -1,11 +1,18 @@
 package client
 
 import (
+	"fmt"
+	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
 )
 
 func SetDebuglogLevel() {
@@ -33,6 +40,69 @@ func genereateJobSpec(jobName string, jobConfig JobConfig) *batchv1.Job {
 	return jobSpec
 }
 
+func generateResource(jobName string, check *StatusCheck, resourceConfig ResourceConfig) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": resourceConfig.Spec,
+		},
+	}
+	labels := make(map[string]string)
+
+	for key, value := range resourceConfig.ObjectMeta.Labels {
+		labels[key] = value
+	}
+
+	for key, value := range resourceConfig.Labels {
+		labels[key] = value
+	}
+
+	resource.SetAPIVersion(check.GroupVersion().String())
+	resource.SetKind(check.Kind)
+	resource.SetName(jobName)
+	resource.SetNamespace(resourceConfig.ObjectMeta.Namespace)
+	resource.SetAnnotations(resourceConfig.ObjectMeta.Annotations)
+	resource.SetLabels(labels)
+	return resource
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("user %s is not allowed to run this job: %s", e.User, e.Err)
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return e.Err
+}
+
+func impersonationError(err error, impersonate *rest.ImpersonationConfig) error {
+	if err != nil && impersonate != nil && apierrors.IsForbidden(err) {
+		return &ForbiddenError{User: impersonate.UserName, Err: err}
+	}
+
+	return err
+}
+
+// ImpersonationFromHeader reads the user and the comma separated groups set
+// by an authenticating proxy.
+func ImpersonationFromHeader(header http.Header, userHeader string, groupsHeader string) (*rest.ImpersonationConfig, error) {
+	user := header.Get(userHeader)
+
+	if user == "" {
+		return nil, errors.Errorf("no authenticated user to run the job as, %s header is missing", userHeader)
+	}
+
+	var groups []string
+
+	for _, value := range header.Values(groupsHeader) {
+		for _, group := range strings.Split(value, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	return &rest.ImpersonationConfig{UserName: user, Groups: groups}, nil
+}
+
 func JobIdAsUint(id string) uint {
 	job_uid, _ := strconv.ParseUint(id, 10, 64)
 	return uint(job_uid)
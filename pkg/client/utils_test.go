@@ -0,0 +1,41 @@
+package client
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestImpersonationFromHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		user   string
+		groups []string
+	}{
+		{name: "user", header: http.Header{"X-Forwarded-User": {"jane"}}, user: "jane"},
+		{name: "groups", header: http.Header{"X-Forwarded-User": {"jane"}, "X-Forwarded-Groups": {"dev, ops", "", "admins,"}}, user: "jane", groups: []string{"dev", "ops", "admins"}},
+		{name: "no user", header: http.Header{"X-Forwarded-Groups": {"dev"}}},
+	}
+
+	for _, test := range tests {
+		impersonate, err := ImpersonationFromHeader(test.header, "X-Forwarded-User", "X-Forwarded-Groups")
+
+		if test.user == "" {
+			if err == nil {
+				t.Errorf("%s: impersonating %+v without a user", test.name, impersonate)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+
+		if impersonate.UserName != test.user || !reflect.DeepEqual(impersonate.Groups, test.groups) {
+			t.Errorf("%s: got %+v", test.name, impersonate)
+		}
+	}
+}
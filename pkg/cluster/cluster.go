@@ -0,0 +1,207 @@
+package cluster
+
+import (
+	"github.com/infor-design/selfservice/pkg/db"
+	"github.com/infor-design/selfservice/pkg/secret"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func NewService(db *db.Connection) *Service {
+	return &Service{
+		db: db,
+	}
+}
+
+func (s *Service) List() []Cluster {
+	var clusters []db.Cluster
+	s.db.Find(&clusters)
+
+	result := make([]Cluster, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		result = append(result, AsCluster(cluster))
+	}
+
+	return result
+}
+
+func (s *Service) Create(payload ClusterCreate) (db.Cluster, error) {
+	cluster := db.Cluster{}
+	err := apply(&cluster, ClusterUpdate(payload))
+
+	if err != nil {
+		return cluster, err
+	}
+
+	err = s.db.Create(&cluster).Error
+	return cluster, err
+}
+
+func (s *Service) Get(id uint) (db.Cluster, error) {
+	cluster := db.Cluster{}
+	err := s.db.First(&cluster, id).Error
+
+	if err != nil {
+		return cluster, err
+	}
+
+	return cluster, nil
+}
+
+// Update replaces the credentials of a cluster. Secrets which are left empty
+// in the payload keep their stored value.
+func (s *Service) Update(cluster db.Cluster, payload ClusterUpdate) (db.Cluster, error) {
+	err := apply(&cluster, payload)
+
+	if err != nil {
+		return cluster, err
+	}
+
+	err = s.db.Save(&cluster).Error
+	return cluster, err
+}
+
+func (s *Service) Delete(cluster db.Cluster) error {
+	err := s.db.Exec("DELETE FROM application_clusters WHERE cluster_id = ?", cluster.ID).Error
+
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Unscoped().Delete(&cluster).Error
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RestConfig builds the client configuration of a registered cluster,
+// decrypting its stored credentials.
+func (s *Service) RestConfig(id uint) (*rest.Config, error) {
+	cluster, err := s.Get(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return restConfig(cluster)
+}
+
+func restConfig(cluster db.Cluster) (*rest.Config, error) {
+	if cluster.InCluster {
+		return rest.InClusterConfig()
+	}
+
+	if len(cluster.Kubeconfig) > 0 {
+		kubeconfig, err := secret.Decrypt(cluster.Kubeconfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		config, err := clientcmd.Load(kubeconfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: cluster.Context}
+		return clientcmd.NewNonInteractiveClientConfig(*config, cluster.Context, overrides, nil).ClientConfig()
+	}
+
+	token, err := secret.Decrypt(cluster.Token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rest.Config{
+		Host:        cluster.Server,
+		BearerToken: string(token),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: []byte(cluster.CAData),
+		},
+	}, nil
+}
+
+// Bound resolves the cluster a job of the application runs on. Jobs of
+// applications without clusters run on the default cluster (0), and the
+// cluster may be omitted when the application is bound to a single one.
+func Bound(app db.Application, clusterId uint) (uint, error) {
+	if len(app.Clusters) == 0 && clusterId == 0 {
+		return 0, nil
+	}
+
+	if len(app.Clusters) == 1 && clusterId == 0 {
+		return app.Clusters[0].ID, nil
+	}
+
+	for _, c := range app.Clusters {
+		if c.ID == clusterId {
+			return clusterId, nil
+		}
+	}
+
+	return 0, errors.Errorf("application %s is not bound to cluster %d", app.Name, clusterId)
+}
+
+func AsCluster(cluster db.Cluster) Cluster {
+	return Cluster{
+		Id:         int(cluster.ID),
+		Name:       cluster.Name,
+		Server:     cluster.Server,
+		Context:    cluster.Context,
+		InCluster:  cluster.InCluster,
+		Created_At: cluster.CreatedAt.String(),
+		Updated_At: cluster.UpdatedAt.String(),
+		Deleted_At: cluster.DeletedAt.Time.String(),
+	}
+}
+
+func apply(cluster *db.Cluster, payload ClusterUpdate) error {
+	if payload.Name == "" {
+		return errors.New("cluster name must be set")
+	}
+
+	cluster.Name = payload.Name
+	cluster.Context = payload.Context
+	cluster.InCluster = payload.InCluster
+	cluster.Server = payload.Server
+	cluster.CAData = payload.CAData
+
+	if len(payload.Kubeconfig) > 0 {
+		_, err := clientcmd.Load([]byte(payload.Kubeconfig))
+
+		if err != nil {
+			return errors.Wrap(err, "invalid kubeconfig")
+		}
+
+		kubeconfig, err := secret.Encrypt([]byte(payload.Kubeconfig))
+
+		if err != nil {
+			return err
+		}
+
+		cluster.Kubeconfig = kubeconfig
+	}
+
+	if len(payload.Token) > 0 {
+		token, err := secret.Encrypt([]byte(payload.Token))
+
+		if err != nil {
+			return err
+		}
+
+		cluster.Token = token
+	}
+
+	if !cluster.InCluster && len(cluster.Kubeconfig) == 0 && (cluster.Server == "" || len(cluster.Token) == 0) {
+		return errors.New("cluster requires a kubeconfig, in_cluster or a server and token")
+	}
+
+	return nil
+}
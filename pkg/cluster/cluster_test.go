@@ -0,0 +1,272 @@
+package cluster
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/infor-design/selfservice/pkg/db"
+	"github.com/infor-design/selfservice/pkg/secret"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+- name: production
+  cluster:
+    server: https://production.example.com
+users:
+- name: deployer
+  user:
+    token: deployer-token
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: deployer
+- name: production
+  context:
+    cluster: production
+    user: deployer
+current-context: staging
+`
+
+func setSecretKey(t *testing.T) {
+	t.Helper()
+	t.Setenv(secret.SECRET_KEY, base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+}
+
+// dryRunService returns a service whose statements are built but never sent
+// to the database, and the statements it runs.
+func dryRunService(t *testing.T) (*Service, *[]string) {
+	t.Helper()
+	gormDb, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var statements []string
+	record := func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}
+
+	for _, err := range []error{
+		gormDb.Callback().Create().After("gorm:create").Register("test:create", record),
+		gormDb.Callback().Update().After("gorm:update").Register("test:update", record),
+		gormDb.Callback().Delete().After("gorm:delete").Register("test:delete", record),
+		gormDb.Callback().Raw().After("gorm:raw").Register("test:raw", record),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewService(&db.Connection{DB: gormDb}), &statements
+}
+
+func TestApply(t *testing.T) {
+	setSecretKey(t)
+	tests := []struct {
+		name    string
+		payload ClusterUpdate
+		err     string
+	}{
+		{name: "kubeconfig", payload: ClusterUpdate{Name: "staging", Kubeconfig: kubeconfig, Context: "production"}},
+		{name: "in cluster", payload: ClusterUpdate{Name: "local", InCluster: true}},
+		{name: "server and token", payload: ClusterUpdate{Name: "remote", Server: "https://remote.example.com", Token: "token"}},
+		{name: "no name", payload: ClusterUpdate{InCluster: true}, err: "cluster name must be set"},
+		{name: "invalid kubeconfig", payload: ClusterUpdate{Name: "staging", Kubeconfig: "clusters: ["}, err: "invalid kubeconfig"},
+		{name: "server without token", payload: ClusterUpdate{Name: "remote", Server: "https://remote.example.com"}, err: "cluster requires a kubeconfig, in_cluster or a server and token"},
+		{name: "no credentials", payload: ClusterUpdate{Name: "remote"}, err: "cluster requires a kubeconfig, in_cluster or a server and token"},
+	}
+
+	for _, test := range tests {
+		err := apply(&db.Cluster{}, test.payload)
+
+		if test.err == "" && err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+
+		if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: got error %v, want %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestApplyEncryptsSecrets(t *testing.T) {
+	setSecretKey(t)
+	cluster := db.Cluster{}
+	err := apply(&cluster, ClusterUpdate{Name: "remote", Server: "https://remote.example.com", Token: "token"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cluster.Token) == 0 || strings.Contains(string(cluster.Token), "token") {
+		t.Fatalf("stored token %q", cluster.Token)
+	}
+
+	stored := string(cluster.Token)
+	err = apply(&cluster, ClusterUpdate{Name: "renamed", Server: "https://remote.example.com"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cluster.Name != "renamed" || string(cluster.Token) != stored {
+		t.Errorf("an update without a token changed the cluster to %+v", cluster)
+	}
+
+	token, err := secret.Decrypt(cluster.Token)
+
+	if err != nil || string(token) != "token" {
+		t.Errorf("got token %q, %v", token, err)
+	}
+}
+
+func TestApplyWithoutSecretKey(t *testing.T) {
+	t.Setenv(secret.SECRET_KEY_FILE, "")
+	t.Setenv(secret.SECRET_KEY, "")
+	err := apply(&db.Cluster{}, ClusterUpdate{Name: "remote", Server: "https://remote.example.com", Token: "token"})
+
+	if err == nil {
+		t.Fatal("stored a token without a secret key")
+	}
+}
+
+func TestRestConfig(t *testing.T) {
+	setSecretKey(t)
+	tests := []struct {
+		name    string
+		payload ClusterUpdate
+		host    string
+		token   string
+	}{
+		{name: "current context", payload: ClusterUpdate{Name: "staging", Kubeconfig: kubeconfig}, host: "https://staging.example.com", token: "deployer-token"},
+		{name: "context", payload: ClusterUpdate{Name: "production", Kubeconfig: kubeconfig, Context: "production"}, host: "https://production.example.com", token: "deployer-token"},
+		{name: "server and token", payload: ClusterUpdate{Name: "remote", Server: "https://remote.example.com", Token: "token", CAData: "ca"}, host: "https://remote.example.com", token: "token"},
+	}
+
+	for _, test := range tests {
+		cluster := db.Cluster{}
+		err := apply(&cluster, test.payload)
+
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		config, err := restConfig(cluster)
+
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		if config.Host != test.host || config.BearerToken != test.token || string(config.CAData) != test.payload.CAData {
+			t.Errorf("%s: got host %s, token %q and ca %q", test.name, config.Host, config.BearerToken, config.CAData)
+		}
+	}
+
+	cluster := db.Cluster{}
+	err := apply(&cluster, ClusterUpdate{Name: "missing", Kubeconfig: kubeconfig, Context: "missing"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := restConfig(cluster); err == nil {
+		t.Error("built a config for a missing context")
+	}
+}
+
+func TestBound(t *testing.T) {
+	unbound := db.Application{Name: "api"}
+	single := db.Application{Name: "api", Clusters: []db.Cluster{{ID: 2}}}
+	several := db.Application{Name: "api", Clusters: []db.Cluster{{ID: 2}, {ID: 3}}}
+	tests := []struct {
+		name      string
+		app       db.Application
+		clusterId uint
+		want      uint
+		err       bool
+	}{
+		{name: "default cluster", app: unbound},
+		{name: "unbound cluster", app: unbound, clusterId: 2, err: true},
+		{name: "single cluster", app: single, want: 2},
+		{name: "named single cluster", app: single, clusterId: 2, want: 2},
+		{name: "other cluster", app: single, clusterId: 3, err: true},
+		{name: "one of several", app: several, clusterId: 3, want: 3},
+		{name: "several clusters", app: several, err: true},
+	}
+
+	for _, test := range tests {
+		clusterId, err := Bound(test.app, test.clusterId)
+
+		if (err != nil) != test.err || clusterId != test.want {
+			t.Errorf("%s: got cluster %d, error %v", test.name, clusterId, err)
+		}
+	}
+}
+
+func TestAsCluster(t *testing.T) {
+	setSecretKey(t)
+	cluster := db.Cluster{ID: 4}
+	err := apply(&cluster, ClusterUpdate{Name: "remote", Server: "https://remote.example.com", Token: "token", CAData: "ca"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listed := AsCluster(cluster)
+
+	if listed.Id != 4 || listed.Name != "remote" || listed.Server != "https://remote.example.com" {
+		t.Errorf("got %+v", listed)
+	}
+}
+
+func TestCreateUpdateDelete(t *testing.T) {
+	setSecretKey(t)
+	service, statements := dryRunService(t)
+	_, err := service.Create(ClusterCreate{Name: "remote"})
+
+	if err == nil || len(*statements) != 0 {
+		t.Fatalf("created an invalid cluster: %v, %v", err, *statements)
+	}
+
+	created, err := service.Create(ClusterCreate{Name: "remote", Server: "https://remote.example.com", Token: "token"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*statements) != 1 || !strings.HasPrefix((*statements)[0], `INSERT INTO "clusters"`) {
+		t.Fatalf("creating ran %v", *statements)
+	}
+
+	created.ID = 4
+	_, err = service.Update(created, ClusterUpdate{Name: "remote"})
+
+	if err == nil || !strings.Contains(err.Error(), "cluster requires") {
+		t.Fatalf("updated to a cluster without a server, got %v", err)
+	}
+
+	*statements = nil
+	err = service.Delete(created)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*statements) != 2 || !strings.Contains((*statements)[0], "application_clusters") || !strings.HasPrefix((*statements)[1], `DELETE FROM "clusters"`) {
+		t.Fatalf("deleting ran %v", *statements)
+	}
+}
@@ -0,0 +1,40 @@
+package cluster
+
+import "github.com/infor-design/selfservice/pkg/db"
+
+type Cluster struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	Server     string `json:"server"`
+	Context    string `json:"context"`
+	InCluster  bool   `json:"in_cluster"`
+	Created_At string `json:"created_at"`
+	Updated_At string `json:"updated_at"`
+	Deleted_At string `json:"deleted_at"`
+}
+
+// ClusterCreate holds the credentials of a cluster. Either Kubeconfig (with an
+// optional Context), InCluster, or Server with a Token must be set.
+type ClusterCreate struct {
+	Name       string `json:"name"`
+	Kubeconfig string `json:"kubeconfig"`
+	Context    string `json:"context"`
+	InCluster  bool   `json:"in_cluster"`
+	Server     string `json:"server"`
+	Token      string `json:"token"`
+	CAData     string `json:"ca_data"`
+}
+
+type ClusterUpdate struct {
+	Name       string `json:"name"`
+	Kubeconfig string `json:"kubeconfig"`
+	Context    string `json:"context"`
+	InCluster  bool   `json:"in_cluster"`
+	Server     string `json:"server"`
+	Token      string `json:"token"`
+	CAData     string `json:"ca_data"`
+}
+
+type Service struct {
+	db *db.Connection
+}
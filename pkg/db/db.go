@@ -72,9 +72,11 @@ func (c *Connection) Close() (err error) {
 }
 
 func (c *Connection) InitialMigration() {
+	c.AutoMigrate(&Cluster{})
 	c.AutoMigrate(&Application{})
 	c.AutoMigrate(&Job{})
 	c.AutoMigrate(&Repo{})
+	c.AutoMigrate(&Promotion{})
 
 	if !c.Migrator().HasConstraint(&Application{}, "Jobs") {
 		c.Migrator().CreateConstraint(&Application{}, "Jobs")
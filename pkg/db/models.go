@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -8,10 +10,19 @@ import (
 type Application struct {
 	ID           uint `gorm:"primary_key" json:"id"`
 	gorm.Model   `json:"model"`
-	Name         string `json:"name"`
-	RepoID       uint   `json:"repo_id"`
-	ManifestPath string `json:"manifest_path"`
-	Status       int    `json:"status"`
+	Name         string         `json:"name"`
+	RepoID       uint           `json:"repo_id"`
+	ManifestPath string         `json:"manifest_path"`
+	Ref          string         `json:"ref"`
+	Description  string         `json:"description"`
+	Icon         string         `json:"icon"`
+	Owners       datatypes.JSON `json:"owners"`
+	Namespace    string         `json:"namespace"`
+	Source       string         `json:"source"`
+	ArchivedAt   *time.Time     `json:"archived_at"`
+	Status       int            `json:"status"`
+	StatusCheck  datatypes.JSON `json:"status_check"`
+	Clusters     []Cluster      `gorm:"many2many:application_clusters;" json:"clusters"`
 	Jobs         []Job
 }
 
@@ -20,15 +31,59 @@ type Job struct {
 	gorm.Model    `json:"model"`
 	Name          string         `json:"name"`
 	ApplicationID uint           `json:"application_id"`
+	ClusterID     uint           `json:"cluster_id"`
 	Phase         string         `json:"phase"`
+	ManifestHash  string         `json:"manifest_hash"`
 	Spec          datatypes.JSON `json:"spec"`
 	Meta          datatypes.JSON `json:"meta"`
 }
 
 type Repo struct {
+	ID                   uint `gorm:"primary_key" json:"id"`
+	gorm.Model           `json:"model"`
+	Url                  string     `json:"url"`
+	Hash                 string     `json:"hash"`
+	Commit               string     `json:"commit"`
+	Ref                  string     `json:"ref"`
+	AuthMethod           string     `json:"auth_method"`
+	WebhookSecret        []byte     `json:"-"`
+	SyncInterval         int        `json:"sync_interval"`
+	CloneDepth           int        `json:"clone_depth"`
+	SingleBranch         bool       `json:"single_branch"`
+	Sparse               bool       `json:"sparse"`
+	Submodules           bool       `json:"submodules"`
+	Lfs                  bool       `json:"lfs"`
+	RequireSignedCommits bool       `json:"require_signed_commits"`
+	Signer               string     `json:"signer"`
+	RequirePromotion     bool       `json:"require_promotion"`
+	PendingHash          string     `json:"pending_hash"`
+	PendingCommit        string     `json:"pending_commit"`
+	PendingSigner        string     `json:"pending_signer"`
+	LastSyncAt           *time.Time `json:"last_sync_at"`
+	LastSuccessAt        *time.Time `json:"last_success_at"`
+	LastError            string     `json:"last_error"`
+	Failures             int        `json:"failures"`
+}
+
+// Promotion is a commit of a repo which became live, by a sync or, for repos
+// requiring promotion, by an admin.
+type Promotion struct {
 	ID         uint `gorm:"primary_key" json:"id"`
 	gorm.Model `json:"model"`
-	Url        string `json:"url"`
+	RepoID     uint   `json:"repo_id"`
 	Hash       string `json:"hash"`
 	Commit     string `json:"commit"`
+	Signer     string `json:"signer"`
+}
+
+type Cluster struct {
+	ID         uint `gorm:"primary_key" json:"id"`
+	gorm.Model `json:"model"`
+	Name       string `json:"name"`
+	Server     string `json:"server"`
+	Context    string `json:"context"`
+	InCluster  bool   `json:"in_cluster"`
+	CAData     string `json:"ca_data"`
+	Kubeconfig []byte `json:"-"`
+	Token      []byte `json:"-"`
 }
@@ -17,7 +17,7 @@ func (s *JobService) GetAllByAppId(appId uint) ([]db.Job, error) {
 }
 
 func (s *JobService) Create(data Job) db.Job {
-	job := db.Job{Name: data.Name, ApplicationID: data.ApplicationID}
+	job := db.Job{Name: data.Name, ApplicationID: data.ApplicationID, ClusterID: data.ClusterID, ManifestHash: data.ManifestHash}
 	s.db.Create(&job)
 	return job
 }
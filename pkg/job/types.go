@@ -4,11 +4,20 @@ import (
 	"github.com/infor-design/selfservice/pkg/db"
 )
 
+const (
+	PhasePending   = "Pending"
+	PhaseRunning   = "Running"
+	PhaseSucceeded = "Succeeded"
+	PhaseFailed    = "Failed"
+)
+
 type Job struct {
 	Id            int    `json:"id"`
 	Name          string `json:"name"`
 	ApplicationID uint   `json:"application_id"`
+	ClusterID     uint   `json:"cluster_id"`
 	Phase         string `json:"phase"`
+	ManifestHash  string `json:"manifest_hash"`
 	Spec          string `json:"spec"`
 	Created_At    string `json:"created_at"`
 	Updated_At    string `json:"updated_at"`
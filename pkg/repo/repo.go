@@ -1,7 +1,11 @@
 package repo
 
 import (
+	"time"
+
 	"github.com/infor-design/selfservice/pkg/db"
+	"github.com/infor-design/selfservice/pkg/secret"
+	"github.com/pkg/errors"
 )
 
 func NewService(db *db.Connection) *Service {
@@ -16,8 +20,28 @@ func (s *Service) List() []db.Repo {
 	return repos
 }
 
+// ListStrict lists the repos like List, failing rather than returning no
+// repos when they cannot be read.
+func (s *Service) ListStrict() ([]db.Repo, error) {
+	var repos []db.Repo
+	err := s.db.Find(&repos).Error
+	return repos, err
+}
+
 func (s *Service) Create(payload Repo) db.Repo {
-	repo := db.Repo{Url: payload.Url}
+	repo := db.Repo{
+		Url:                  payload.Url,
+		Ref:                  payload.Ref,
+		AuthMethod:           payload.AuthMethod,
+		SyncInterval:         payload.SyncInterval,
+		CloneDepth:           payload.CloneDepth,
+		SingleBranch:         payload.SingleBranch,
+		Sparse:               payload.Sparse,
+		Submodules:           payload.Submodules,
+		Lfs:                  payload.Lfs,
+		RequireSignedCommits: payload.RequireSignedCommits,
+		RequirePromotion:     payload.RequirePromotion,
+	}
 	s.db.Create(&repo)
 	return repo
 }
@@ -43,8 +67,258 @@ func (s *Service) Update(repo db.Repo) error {
 	return nil
 }
 
+const maxSyncBackoff = 24 * time.Hour
+
+var (
+	// ErrPinnedPromotion refuses pinning the applications of repos requiring
+	// promotion, as only commits of the ref of the repo are promoted.
+	ErrPinnedPromotion = errors.New("applications of repos requiring promotion cannot be pinned to another ref than the repo")
+
+	liveColumns    = []string{"Hash", "Commit", "Signer"}
+	pendingColumns = []string{"PendingHash", "PendingCommit", "PendingSigner"}
+	statusColumns  = []string{"LastSyncAt", "LastSuccessAt", "LastError", "Failures"}
+)
+
+// RecordSync stores the outcome of a sync attempt. Only the sync status is
+// written, so concurrent edits of the repo are kept. Repos requiring promotion
+// keep serving their promoted commit, a new commit is pending until promoted.
+// The first commit of a repo is promoted by its sync. A synced commit only
+// becomes live if the repo was not promoted or rolled back during the sync,
+// otherwise the sync is recorded again against the repo as it is now.
+func (s *Service) RecordSync(repo db.Repo, hash string, commit string, signer string, syncErr error) (db.Repo, error) {
+	previous := repo.Hash
+	repo, columns, live := applySync(repo, hash, commit, signer, syncErr, time.Now())
+	query := s.db.Model(&repo)
+
+	if live {
+		query = query.Where("hash = ?", previous)
+	}
+
+	result := query.Select(columns).Updates(&repo)
+
+	if result.Error != nil {
+		return repo, result.Error
+	}
+
+	if live && result.RowsAffected == 0 {
+		current, err := s.Get(repo.ID)
+
+		if err != nil {
+			return repo, err
+		}
+
+		return s.RecordSync(current, hash, commit, signer, syncErr)
+	}
+
+	if live && previous != hash {
+		err := s.db.Create(&db.Promotion{RepoID: repo.ID, Hash: hash, Commit: commit, Signer: signer}).Error
+
+		if err != nil {
+			return repo, err
+		}
+	}
+
+	return repo, nil
+}
+
+// applySync applies the outcome of a sync to a repo, returning the columns
+// which changed and whether the synced commit became live.
+func applySync(repo db.Repo, hash string, commit string, signer string, syncErr error, now time.Time) (db.Repo, []string, bool) {
+	repo.LastSyncAt = &now
+
+	if syncErr != nil {
+		repo.LastError = syncErr.Error()
+		repo.Failures++
+		return repo, statusColumns, false
+	}
+
+	repo.LastSuccessAt = &now
+	repo.LastError = ""
+	repo.Failures = 0
+
+	if repo.RequirePromotion && repo.Hash != "" && repo.Hash != hash {
+		repo.PendingHash = hash
+		repo.PendingCommit = commit
+		repo.PendingSigner = signer
+		return repo, columns(pendingColumns, statusColumns), false
+	}
+
+	repo.Hash = hash
+	repo.Commit = commit
+	repo.Signer = signer
+	repo.PendingHash = ""
+	repo.PendingCommit = ""
+	repo.PendingSigner = ""
+	return repo, columns(liveColumns, pendingColumns, statusColumns), true
+}
+
+// Promote makes a commit the live commit of a repo. The pending commit is
+// kept when an earlier commit is promoted to roll back, or when a sync made
+// another commit pending meanwhile. Promoting the live commit again changes
+// nothing.
+func (s *Service) Promote(repo db.Repo, hash string, commit string, signer string) (db.Repo, error) {
+	if hash == repo.Hash {
+		return repo, nil
+	}
+
+	repo, clearPending := applyPromotion(repo, hash, commit, signer)
+	err := s.db.Create(&db.Promotion{RepoID: repo.ID, Hash: hash, Commit: commit, Signer: signer}).Error
+
+	if err != nil {
+		return repo, err
+	}
+
+	err = s.db.Model(&repo).Select(liveColumns).Updates(&repo).Error
+
+	if err != nil {
+		return repo, err
+	}
+
+	if clearPending {
+		err = s.db.Model(&repo).Where("pending_hash = ?", hash).Select(pendingColumns).Updates(&repo).Error
+
+		if err != nil {
+			return repo, err
+		}
+	}
+
+	return s.Get(repo.ID)
+}
+
+// applyPromotion makes a commit live, reporting whether it was the pending
+// commit, which is cleared.
+func applyPromotion(repo db.Repo, hash string, commit string, signer string) (db.Repo, bool) {
+	repo.Hash = hash
+	repo.Commit = commit
+	repo.Signer = signer
+
+	if repo.PendingHash != hash {
+		return repo, false
+	}
+
+	repo.PendingHash = ""
+	repo.PendingCommit = ""
+	repo.PendingSigner = ""
+	return repo, true
+}
+
+// CheckPinnedRef refuses pinning an application to another ref than its repo
+// when the repo requires promotion.
+func CheckPinnedRef(repo db.Repo, ref string) error {
+	if repo.RequirePromotion && ref != "" && ref != repo.Ref {
+		return ErrPinnedPromotion
+	}
+
+	return nil
+}
+
+// PinnedRefs lists the refs other than the one tracked by the repo which its
+// applications are pinned to, each once. Applications without a ref follow
+// the repo.
+func PinnedRefs(repo db.Repo, applications []db.Application) []string {
+	var refs []string
+	listed := map[string]bool{"": true, repo.Ref: true}
+
+	for _, app := range applications {
+		if listed[app.Ref] {
+			continue
+		}
+
+		listed[app.Ref] = true
+		refs = append(refs, app.Ref)
+	}
+
+	return refs
+}
+
+// ManifestsRef is the ref the manifests of an application are read at: the
+// ref it is pinned to, or the ref tracked by its repo. Applications of repos
+// requiring promotion always follow the ref of the repo.
+func ManifestsRef(repo db.Repo, app db.Application) string {
+	if app.Ref == "" || repo.RequirePromotion {
+		return repo.Ref
+	}
+
+	return app.Ref
+}
+
+// NextSync returns when a repo is due, every interval unless the repo sets
+// its own. Repos which keep failing back off exponentially, up to a day.
+func NextSync(repo db.Repo, interval time.Duration) time.Time {
+	if repo.LastSyncAt == nil {
+		return time.Time{}
+	}
+
+	if repo.SyncInterval > 0 {
+		interval = time.Duration(repo.SyncInterval) * time.Minute
+	}
+
+	for i := 0; i < repo.Failures && interval < maxSyncBackoff; i++ {
+		interval *= 2
+	}
+
+	if interval > maxSyncBackoff {
+		interval = maxSyncBackoff
+	}
+
+	return repo.LastSyncAt.Add(interval)
+}
+
+func columns(groups ...[]string) []string {
+	var all []string
+
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+
+	return all
+}
+
+// Promotions lists the commits promoted for a repo, latest first.
+func (s *Service) Promotions(repo db.Repo) ([]db.Promotion, error) {
+	var promotions []db.Promotion
+	err := s.db.Where("repo_id = ?", repo.ID).Order("id desc").Find(&promotions).Error
+	return promotions, err
+}
+
+// Promotable reports whether a commit may be promoted: the pending commit,
+// the live one or one promoted before.
+func (s *Service) Promotable(repo db.Repo, hash string) (bool, error) {
+	if hash == repo.PendingHash || hash == repo.Hash {
+		return hash != "", nil
+	}
+
+	var count int64
+	err := s.db.Model(&db.Promotion{}).Where("repo_id = ? AND hash = ?", repo.ID, hash).Count(&count).Error
+	return count > 0, err
+}
+
+// SetWebhookSecret stores the encrypted secret push webhooks of the repo are
+// signed with.
+func (s *Service) SetWebhookSecret(repo db.Repo, webhookSecret string) (db.Repo, error) {
+	encrypted, err := secret.Encrypt([]byte(webhookSecret))
+
+	if err != nil {
+		return repo, err
+	}
+
+	repo.WebhookSecret = encrypted
+	err = s.db.Save(&repo).Error
+	return repo, err
+}
+
+func (s *Service) WebhookSecret(repo db.Repo) ([]byte, error) {
+	return secret.Decrypt(repo.WebhookSecret)
+}
+
 func (s *Service) Delete(repo db.Repo) error {
-	err := s.db.Unscoped().Delete(&repo).Error
+	err := s.db.Unscoped().Where("repo_id = ?", repo.ID).Delete(&db.Promotion{}).Error
+
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Unscoped().Delete(&repo).Error
 
 	if err != nil {
 		return err
@@ -0,0 +1,214 @@
+package repo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/infor-design/selfservice/pkg/db"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunService returns a service whose statements are built but never sent
+// to the database, and the rows it creates.
+func dryRunService(t *testing.T) (*Service, *[]interface{}) {
+	t.Helper()
+	gormDb, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var created []interface{}
+	err = gormDb.Callback().Create().Before("gorm:create").Register("test:created", func(tx *gorm.DB) {
+		created = append(created, tx.Statement.Dest)
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewService(&db.Connection{DB: gormDb}), &created
+}
+
+func TestApplySyncPending(t *testing.T) {
+	repo := db.Repo{RequirePromotion: true, Hash: "a", Commit: "first"}
+	repo, columns, live := applySync(repo, "b", "second", "alice", nil, time.Now())
+
+	if live || repo.Hash != "a" || repo.PendingHash != "b" || repo.PendingCommit != "second" || repo.PendingSigner != "alice" {
+		t.Fatalf("got live %v, repo %+v", live, repo)
+	}
+
+	if !reflect.DeepEqual(columns, []string{"PendingHash", "PendingCommit", "PendingSigner", "LastSyncAt", "LastSuccessAt", "LastError", "Failures"}) {
+		t.Fatalf("pending sync writes %v", columns)
+	}
+}
+
+func TestApplySyncLive(t *testing.T) {
+	for name, repo := range map[string]db.Repo{
+		"first commit of a gated repo": {RequirePromotion: true},
+		"ungated repo":                 {Hash: "a", PendingHash: "c"},
+		"live commit of a gated repo":  {RequirePromotion: true, Hash: "b", PendingHash: "c"},
+	} {
+		repo, columns, live := applySync(repo, "b", "second", "", nil, time.Now())
+
+		if !live || repo.Hash != "b" || repo.Commit != "second" || repo.PendingHash != "" {
+			t.Errorf("%s: got live %v, repo %+v", name, live, repo)
+		}
+
+		if columns[0] != "Hash" {
+			t.Errorf("%s: live sync writes %v", name, columns)
+		}
+	}
+}
+
+func TestApplySyncError(t *testing.T) {
+	repo := db.Repo{Hash: "a", PendingHash: "b", Failures: 1}
+	repo, columns, live := applySync(repo, "", "", "", errors.New("unreachable"), time.Now())
+
+	if live || repo.Hash != "a" || repo.PendingHash != "b" || repo.Failures != 2 || repo.LastError != "unreachable" {
+		t.Fatalf("got live %v, repo %+v", live, repo)
+	}
+
+	if !reflect.DeepEqual(columns, statusColumns) {
+		t.Fatalf("failed sync writes %v", columns)
+	}
+}
+
+func TestApplyPromotion(t *testing.T) {
+	repo := db.Repo{RequirePromotion: true, Hash: "a", PendingHash: "b", PendingCommit: "second"}
+	promoted, cleared := applyPromotion(repo, "b", "second", "")
+
+	if !cleared || promoted.Hash != "b" || promoted.PendingHash != "" || promoted.PendingCommit != "" {
+		t.Fatalf("promoting the pending commit: cleared %v, repo %+v", cleared, promoted)
+	}
+
+	rolledBack, cleared := applyPromotion(promoted, "a", "first", "")
+
+	if cleared || rolledBack.Hash != "a" || rolledBack.Commit != "first" {
+		t.Fatalf("rolling back: cleared %v, repo %+v", cleared, rolledBack)
+	}
+
+	repo, cleared = applyPromotion(repo, "z", "earlier", "")
+
+	if cleared || repo.Hash != "z" || repo.PendingHash != "b" {
+		t.Fatalf("rolling back with a pending commit: cleared %v, repo %+v", cleared, repo)
+	}
+}
+
+func TestPromote(t *testing.T) {
+	service, created := dryRunService(t)
+	repo := db.Repo{ID: 1, RequirePromotion: true, Hash: "a", Commit: "first"}
+	promoted, err := service.Promote(repo, "a", "first", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*created) != 0 || promoted.Hash != "a" {
+		t.Fatalf("promoting the live commit created %v, repo %+v", *created, promoted)
+	}
+
+	_, err = service.Promote(repo, "b", "second", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*created) != 1 {
+		t.Fatalf("promoting a commit created %v", *created)
+	}
+
+	promotion, ok := (*created)[0].(*db.Promotion)
+
+	if !ok || promotion.RepoID != 1 || promotion.Hash != "b" || promotion.Commit != "second" {
+		t.Errorf("promoting a commit created %+v", (*created)[0])
+	}
+}
+
+func TestPinnedRefs(t *testing.T) {
+	repo := db.Repo{Ref: "main"}
+	applications := []db.Application{{Ref: ""}, {Ref: "main"}, {Ref: "v1"}, {Ref: "develop"}, {Ref: "v1"}}
+
+	if got := PinnedRefs(repo, applications); !reflect.DeepEqual(got, []string{"v1", "develop"}) {
+		t.Errorf("got %v", got)
+	}
+
+	// Applications without a ref follow the remote HEAD like their repo.
+	if got := PinnedRefs(db.Repo{}, applications); !reflect.DeepEqual(got, []string{"main", "v1", "develop"}) {
+		t.Errorf("got %v for a repo following the remote HEAD", got)
+	}
+
+	if got := PinnedRefs(repo, nil); got != nil {
+		t.Errorf("got %v without applications", got)
+	}
+}
+
+func TestManifestsRef(t *testing.T) {
+	tests := []struct {
+		name string
+		repo db.Repo
+		ref  string
+		want string
+	}{
+		{name: "following the repo", repo: db.Repo{Ref: "main"}, want: "main"},
+		{name: "pinned", repo: db.Repo{Ref: "main"}, ref: "v1", want: "v1"},
+		{name: "pinned following the remote HEAD", ref: "v1", want: "v1"},
+		{name: "pinned in a gated repo", repo: db.Repo{Ref: "main", RequirePromotion: true}, ref: "v1", want: "main"},
+	}
+
+	for _, test := range tests {
+		if got := ManifestsRef(test.repo, db.Application{Ref: test.ref}); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCheckPinnedRef(t *testing.T) {
+	gated := db.Repo{Ref: "main", RequirePromotion: true}
+
+	if err := CheckPinnedRef(gated, "v1"); err != ErrPinnedPromotion {
+		t.Fatalf("pinned an application of a gated repo, got %v", err)
+	}
+
+	for _, ref := range []string{"", "main"} {
+		if err := CheckPinnedRef(gated, ref); err != nil {
+			t.Fatalf("ref %q: %s", ref, err)
+		}
+	}
+
+	if err := CheckPinnedRef(db.Repo{Ref: "main"}, "v1"); err != nil {
+		t.Fatalf("pinning an application of an ungated repo: %s", err)
+	}
+}
+
+func TestNextSync(t *testing.T) {
+	last := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	interval := 15 * time.Minute
+
+	tests := []struct {
+		name string
+		repo db.Repo
+		want time.Time
+	}{
+		{name: "never synced", repo: db.Repo{Failures: 3}, want: time.Time{}},
+		{name: "default interval", repo: db.Repo{LastSyncAt: &last}, want: last.Add(15 * time.Minute)},
+		{name: "own interval", repo: db.Repo{LastSyncAt: &last, SyncInterval: 60}, want: last.Add(time.Hour)},
+		{name: "one failure", repo: db.Repo{LastSyncAt: &last, Failures: 1}, want: last.Add(30 * time.Minute)},
+		{name: "three failures", repo: db.Repo{LastSyncAt: &last, Failures: 3}, want: last.Add(2 * time.Hour)},
+		{name: "backoff capped", repo: db.Repo{LastSyncAt: &last, Failures: 50}, want: last.Add(24 * time.Hour)},
+		{name: "own interval capped", repo: db.Repo{LastSyncAt: &last, SyncInterval: 3000}, want: last.Add(24 * time.Hour)},
+	}
+
+	for _, test := range tests {
+		if got := NextSync(test.repo, interval); !got.Equal(test.want) {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
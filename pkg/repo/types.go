@@ -1,25 +1,77 @@
 package repo
 
-import "github.com/infor-design/selfservice/pkg/db"
+import (
+	"time"
+
+	"github.com/infor-design/selfservice/pkg/db"
+)
 
 type Repo struct {
-	Id         int    `json:"id"`
-	Url        string `json:"url"`
-	Commit     string `json:"commit"`
-	Hash       string `json:"hash"`
-	Created_At string `json:"created_at"`
-	Updated_At string `json:"updated_at"`
-	Deleted_At string `json:"deleted_at"`
+	Id                   int        `json:"id"`
+	Url                  string     `json:"url"`
+	Commit               string     `json:"commit"`
+	Hash                 string     `json:"hash"`
+	Ref                  string     `json:"ref"`
+	AuthMethod           string     `json:"auth_method"`
+	SyncInterval         int        `json:"sync_interval"`
+	CloneDepth           int        `json:"clone_depth"`
+	SingleBranch         bool       `json:"single_branch"`
+	Sparse               bool       `json:"sparse"`
+	Submodules           bool       `json:"submodules"`
+	Lfs                  bool       `json:"lfs"`
+	RequireSignedCommits bool       `json:"require_signed_commits"`
+	Signer               string     `json:"signer"`
+	RequirePromotion     bool       `json:"require_promotion"`
+	PendingHash          string     `json:"pending_hash"`
+	PendingCommit        string     `json:"pending_commit"`
+	PendingSigner        string     `json:"pending_signer"`
+	LastSyncAt           *time.Time `json:"last_sync_at"`
+	LastSuccessAt        *time.Time `json:"last_success_at"`
+	LastError            string     `json:"last_error"`
+	Failures             int        `json:"failures"`
+	Created_At           string     `json:"created_at"`
+	Updated_At           string     `json:"updated_at"`
+	Deleted_At           string     `json:"deleted_at"`
 }
 
 type RepoCreate struct {
-	Url             string `json:"url"`
-	Ssh_Private_Key string `json:"ssh_private_key"`
+	Url                    string `json:"url"`
+	Ref                    string `json:"ref"`
+	Auth_Method            string `json:"auth_method"`
+	Username               string `json:"username"`
+	Password               string `json:"password"`
+	Ssh_Private_Key        string `json:"ssh_private_key"`
+	Ssh_Passphrase         string `json:"ssh_passphrase"`
+	Credential_Helper      string `json:"credential_helper"`
+	Webhook_Secret         string `json:"webhook_secret"`
+	Sync_Interval          int    `json:"sync_interval"`
+	Clone_Depth            int    `json:"clone_depth"`
+	Single_Branch          bool   `json:"single_branch"`
+	Sparse                 bool   `json:"sparse"`
+	Submodules             bool   `json:"submodules"`
+	Lfs                    bool   `json:"lfs"`
+	Require_Signed_Commits bool   `json:"require_signed_commits"`
+	Require_Promotion      bool   `json:"require_promotion"`
 }
 
 type RepoUpdate struct {
-	Url             string `json:"url"`
-	Ssh_Private_Key string `json:"ssh_private_key"`
+	Url                    string `json:"url"`
+	Ref                    string `json:"ref"`
+	Auth_Method            string `json:"auth_method"`
+	Username               string `json:"username"`
+	Password               string `json:"password"`
+	Ssh_Private_Key        string `json:"ssh_private_key"`
+	Ssh_Passphrase         string `json:"ssh_passphrase"`
+	Credential_Helper      string `json:"credential_helper"`
+	Webhook_Secret         string `json:"webhook_secret"`
+	Sync_Interval          int    `json:"sync_interval"`
+	Clone_Depth            int    `json:"clone_depth"`
+	Single_Branch          bool   `json:"single_branch"`
+	Sparse                 bool   `json:"sparse"`
+	Submodules             bool   `json:"submodules"`
+	Lfs                    bool   `json:"lfs"`
+	Require_Signed_Commits bool   `json:"require_signed_commits"`
+	Require_Promotion      bool   `json:"require_promotion"`
 }
 
 type Service struct {
@@ -0,0 +1,179 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SECRET_KEY      = "SECRET_KEY"
+	SECRET_KEY_FILE = "SECRET_KEY_FILE"
+)
+
+// ErrNoKey is returned by Key when neither SECRET_KEY nor SECRET_KEY_FILE is
+// set.
+var ErrNoKey = errors.Errorf("%s or %s must be set to store secrets", SECRET_KEY, SECRET_KEY_FILE)
+
+// envelope is the stored form of data sealed by SealEnvelope.
+type envelope struct {
+	Key  []byte `json:"key"`
+	Data []byte `json:"data"`
+}
+
+// Key returns the base64 encoded 32 byte key used to encrypt secrets at rest,
+// read from SECRET_KEY or the file named by SECRET_KEY_FILE.
+func Key() ([]byte, error) {
+	encoded, exists := os.LookupEnv(SECRET_KEY)
+
+	if !exists {
+		keyFile, exists := os.LookupEnv(SECRET_KEY_FILE)
+
+		if !exists {
+			return nil, ErrNoKey
+		}
+
+		contents, err := os.ReadFile(keyFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = string(contents)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+
+	if err != nil {
+		return nil, errors.Wrap(err, "secret key must be base64 encoded")
+	}
+
+	if len(key) != 32 {
+		return nil, errors.New("secret key must be 32 bytes")
+	}
+
+	return key, nil
+}
+
+// Seal encrypts plaintext with AES-256-GCM, prefixing the random nonce.
+func Seal(key []byte, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open decrypts data produced by Seal.
+func Open(key []byte, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+// SealEnvelope encrypts plaintext with a random data key and stores the data
+// key sealed with key alongside it, so key itself only ever encrypts data keys.
+func SealEnvelope(key []byte, plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, 32)
+
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	sealedKey, err := Seal(key, dataKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := Seal(dataKey, plaintext)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(envelope{Key: sealedKey, Data: data})
+}
+
+// OpenEnvelope decrypts data produced by SealEnvelope.
+func OpenEnvelope(key []byte, sealed []byte) ([]byte, error) {
+	var e envelope
+	err := json.Unmarshal(sealed, &e)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid envelope")
+	}
+
+	dataKey, err := Open(key, e.Key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(dataKey, e.Data)
+}
+
+// Encrypt seals plaintext with the configured secret key. Empty values are
+// left empty.
+func Encrypt(plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return nil, nil
+	}
+
+	key, err := Key()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Seal(key, plaintext)
+}
+
+// Decrypt opens ciphertext with the configured secret key.
+func Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) == 0 {
+		return nil, nil
+	}
+
+	key, err := Key()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(key, ciphertext)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	GITHUB    = "github"
+	GITLAB    = "gitlab"
+	BITBUCKET = "bitbucket"
+	GITEA     = "gitea"
+)
+
+// Push is the provider independent part of a push event.
+type Push struct {
+	Urls          []string
+	Branch        string
+	Tag           string
+	DefaultBranch string
+}
+
+type Debouncer struct {
+	mu     sync.Mutex
+	delay  time.Duration
+	timers map[uint]*time.Timer
+}
+
+type githubPush struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		HtmlUrl       string `json:"html_url"`
+		CloneUrl      string `json:"clone_url"`
+		SshUrl        string `json:"ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+type gitlabPush struct {
+	Ref     string `json:"ref"`
+	Project struct {
+		WebUrl        string `json:"web_url"`
+		GitHttpUrl    string `json:"git_http_url"`
+		GitSshUrl     string `json:"git_ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"project"`
+}
+
+type bitbucketPush struct {
+	Push struct {
+		Changes []struct {
+			New *struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		Links    struct {
+			Html struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	} `json:"repository"`
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var ErrIgnoredEvent = errors.New("event is not a push")
+
+// Parse decodes the push event of a provider. Other events are reported with
+// ErrIgnoredEvent.
+func Parse(provider string, header http.Header, body []byte) (*Push, error) {
+	switch provider {
+	case GITHUB, GITEA:
+		event := header.Get("X-GitHub-Event")
+
+		if provider == GITEA {
+			event = header.Get("X-Gitea-Event")
+		}
+
+		if event != "push" {
+			return nil, ErrIgnoredEvent
+		}
+
+		var payload githubPush
+		err := json.Unmarshal(body, &payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		push := fromRef(payload.Ref)
+		push.Urls = []string{payload.Repository.HtmlUrl, payload.Repository.CloneUrl, payload.Repository.SshUrl}
+		push.DefaultBranch = payload.Repository.DefaultBranch
+		return push, nil
+	case GITLAB:
+		event := header.Get("X-Gitlab-Event")
+
+		if event != "Push Hook" && event != "Tag Push Hook" {
+			return nil, ErrIgnoredEvent
+		}
+
+		var payload gitlabPush
+		err := json.Unmarshal(body, &payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		push := fromRef(payload.Ref)
+		push.Urls = []string{payload.Project.WebUrl, payload.Project.GitHttpUrl, payload.Project.GitSshUrl}
+		push.DefaultBranch = payload.Project.DefaultBranch
+		return push, nil
+	case BITBUCKET:
+		if header.Get("X-Event-Key") != "repo:push" {
+			return nil, ErrIgnoredEvent
+		}
+
+		var payload bitbucketPush
+		err := json.Unmarshal(body, &payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		push := &Push{Urls: []string{payload.Repository.Links.Html.Href}}
+
+		for _, change := range payload.Push.Changes {
+			if change.New == nil {
+				continue
+			}
+
+			if change.New.Type == "tag" {
+				push.Tag = change.New.Name
+			} else {
+				push.Branch = change.New.Name
+			}
+		}
+
+		return push, nil
+	default:
+		return nil, errors.Errorf("unknown provider %q", provider)
+	}
+}
+
+// Verify checks the signature of a delivery against the webhook secret of a
+// repo. GitLab sends the secret itself rather than a signature.
+func Verify(provider string, header http.Header, body []byte, secret []byte) error {
+	if len(secret) == 0 {
+		return errors.New("repo has no webhook secret")
+	}
+
+	switch provider {
+	case GITLAB:
+		if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), secret) != 1 {
+			return errors.New("invalid webhook token")
+		}
+
+		return nil
+	case GITEA:
+		return verifySignature(header.Get("X-Gitea-Signature"), body, secret)
+	case BITBUCKET:
+		return verifySignature(strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256="), body, secret)
+	default:
+		return verifySignature(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="), body, secret)
+	}
+}
+
+func verifySignature(signature string, body []byte, secret []byte) error {
+	expected, err := hex.DecodeString(signature)
+
+	if err != nil || len(expected) == 0 {
+		return errors.New("missing or malformed webhook signature")
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.New("invalid webhook signature")
+	}
+
+	return nil
+}
+
+// Matches reports whether the push is for the repo url.
+func (p *Push) Matches(repoUrl string) bool {
+	for _, u := range p.Urls {
+		if u != "" && NormalizeUrl(u) == NormalizeUrl(repoUrl) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Updates reports whether the push moved ref. An empty ref follows the
+// default branch, which is assumed when the provider does not report it.
+func (p *Push) Updates(ref string) bool {
+	if ref == "" {
+		return p.Branch != "" && (p.DefaultBranch == "" || p.Branch == p.DefaultBranch)
+	}
+
+	return ref == p.Branch || ref == p.Tag
+}
+
+// NormalizeUrl reduces https, ssh and scp-like git urls to host/path.
+func NormalizeUrl(repoUrl string) string {
+	repoUrl = strings.TrimSpace(repoUrl)
+
+	if !strings.Contains(repoUrl, "://") {
+		if at := strings.Index(repoUrl, "@"); at >= 0 {
+			repoUrl = repoUrl[at+1:]
+		}
+
+		repoUrl = "ssh://" + strings.Replace(repoUrl, ":", "/", 1)
+	}
+
+	parsed, err := url.Parse(repoUrl)
+
+	if err != nil {
+		return strings.ToLower(repoUrl)
+	}
+
+	path := strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	return strings.ToLower(parsed.Hostname() + "/" + path)
+}
+
+func fromRef(ref string) *Push {
+	push := &Push{}
+
+	if strings.HasPrefix(ref, "refs/tags/") {
+		push.Tag = strings.TrimPrefix(ref, "refs/tags/")
+	} else {
+		push.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	}
+
+	return push
+}
+
+func NewDebouncer(delay time.Duration) *Debouncer {
+	return &Debouncer{
+		delay:  delay,
+		timers: map[uint]*time.Timer{},
+	}
+}
+
+// Trigger runs fn once no other trigger for the same id happened within the
+// delay, so a burst of pushes results in a single sync.
+func (d *Debouncer) Trigger(id uint, fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if timer, ok := d.timers[id]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d.delay, func() {
+		d.mu.Lock()
+
+		if d.timers[id] == timer {
+			delete(d.timers, id)
+		}
+
+		d.mu.Unlock()
+		fn()
+	})
+	d.timers[id] = timer
+}
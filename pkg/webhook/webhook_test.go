@@ -0,0 +1,209 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func sign(body []byte, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"ref": "refs/heads/main"}`)
+	secret := []byte("s3cret")
+	signature := sign(body, secret)
+	forged := sign(body, []byte("other"))
+
+	tests := []struct {
+		name     string
+		provider string
+		header   http.Header
+		secret   []byte
+		valid    bool
+	}{
+		{name: "github", provider: GITHUB, header: http.Header{"X-Hub-Signature-256": {"sha256=" + signature}}, secret: secret, valid: true},
+		{name: "github forged", provider: GITHUB, header: http.Header{"X-Hub-Signature-256": {"sha256=" + forged}}, secret: secret},
+		{name: "github unsigned", provider: GITHUB, header: http.Header{}, secret: secret},
+		{name: "github malformed", provider: GITHUB, header: http.Header{"X-Hub-Signature-256": {"sha256=zz"}}, secret: secret},
+		{name: "gitea", provider: GITEA, header: http.Header{"X-Gitea-Signature": {signature}}, secret: secret, valid: true},
+		{name: "gitea forged", provider: GITEA, header: http.Header{"X-Gitea-Signature": {forged}}, secret: secret},
+		{name: "bitbucket", provider: BITBUCKET, header: http.Header{"X-Hub-Signature": {"sha256=" + signature}}, secret: secret, valid: true},
+		{name: "bitbucket forged", provider: BITBUCKET, header: http.Header{"X-Hub-Signature": {"sha256=" + forged}}, secret: secret},
+		{name: "gitlab", provider: GITLAB, header: http.Header{"X-Gitlab-Token": {"s3cret"}}, secret: secret, valid: true},
+		{name: "gitlab wrong token", provider: GITLAB, header: http.Header{"X-Gitlab-Token": {"other"}}, secret: secret},
+		{name: "no secret", provider: GITLAB, header: http.Header{"X-Gitlab-Token": {""}}},
+	}
+
+	for _, test := range tests {
+		err := Verify(test.provider, test.header, body, test.secret)
+
+		if test.valid && err != nil {
+			t.Errorf("%s: %s", test.name, err)
+		}
+
+		if !test.valid && err == nil {
+			t.Errorf("%s: verified", test.name)
+		}
+	}
+
+	tampered := []byte(`{"ref": "refs/heads/other"}`)
+
+	if err := Verify(GITHUB, http.Header{"X-Hub-Signature-256": {"sha256=" + signature}}, tampered, secret); err == nil {
+		t.Error("verified a tampered body")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		header   http.Header
+		body     string
+		want     *Push
+	}{
+		{
+			name:     "github branch",
+			provider: GITHUB,
+			header:   http.Header{"X-Github-Event": {"push"}},
+			body:     `{"ref": "refs/heads/main", "repository": {"html_url": "https://github.com/org/repo", "clone_url": "https://github.com/org/repo.git", "ssh_url": "git@github.com:org/repo.git", "default_branch": "main"}}`,
+			want:     &Push{Urls: []string{"https://github.com/org/repo", "https://github.com/org/repo.git", "git@github.com:org/repo.git"}, Branch: "main", DefaultBranch: "main"},
+		},
+		{
+			name:     "gitea tag",
+			provider: GITEA,
+			header:   http.Header{"X-Gitea-Event": {"push"}},
+			body:     `{"ref": "refs/tags/v1.0.0", "repository": {"html_url": "https://gitea.local/org/repo"}}`,
+			want:     &Push{Urls: []string{"https://gitea.local/org/repo", "", ""}, Tag: "v1.0.0"},
+		},
+		{
+			name:     "gitlab",
+			provider: GITLAB,
+			header:   http.Header{"X-Gitlab-Event": {"Push Hook"}},
+			body:     `{"ref": "refs/heads/develop", "project": {"web_url": "https://gitlab.com/org/repo", "git_http_url": "https://gitlab.com/org/repo.git", "git_ssh_url": "git@gitlab.com:org/repo.git", "default_branch": "main"}}`,
+			want:     &Push{Urls: []string{"https://gitlab.com/org/repo", "https://gitlab.com/org/repo.git", "git@gitlab.com:org/repo.git"}, Branch: "develop", DefaultBranch: "main"},
+		},
+		{
+			name:     "gitlab tag",
+			provider: GITLAB,
+			header:   http.Header{"X-Gitlab-Event": {"Tag Push Hook"}},
+			body:     `{"ref": "refs/tags/v2", "project": {"web_url": "https://gitlab.com/org/repo"}}`,
+			want:     &Push{Urls: []string{"https://gitlab.com/org/repo", "", ""}, Tag: "v2"},
+		},
+		{
+			name:     "bitbucket",
+			provider: BITBUCKET,
+			header:   http.Header{"X-Event-Key": {"repo:push"}},
+			body:     `{"push": {"changes": [{"new": null}, {"new": {"type": "branch", "name": "main"}}, {"new": {"type": "tag", "name": "v3"}}]}, "repository": {"links": {"html": {"href": "https://bitbucket.org/org/repo"}}}}`,
+			want:     &Push{Urls: []string{"https://bitbucket.org/org/repo"}, Branch: "main", Tag: "v3"},
+		},
+	}
+
+	for _, test := range tests {
+		push, err := Parse(test.provider, test.header, []byte(test.body))
+
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(push, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, push, test.want)
+		}
+	}
+}
+
+func TestParseIgnoredAndInvalid(t *testing.T) {
+	ignored := []struct {
+		provider string
+		header   http.Header
+	}{
+		{GITHUB, http.Header{"X-Github-Event": {"ping"}}},
+		{GITEA, http.Header{"X-Github-Event": {"push"}}},
+		{GITLAB, http.Header{"X-Gitlab-Event": {"Merge Request Hook"}}},
+		{BITBUCKET, http.Header{"X-Event-Key": {"pullrequest:created"}}},
+	}
+
+	for _, test := range ignored {
+		if _, err := Parse(test.provider, test.header, []byte(`{}`)); err != ErrIgnoredEvent {
+			t.Errorf("%s %v: got %v, want ErrIgnoredEvent", test.provider, test.header, err)
+		}
+	}
+
+	if _, err := Parse(GITHUB, http.Header{"X-Github-Event": {"push"}}, []byte(`{`)); err == nil {
+		t.Error("parsed an invalid body")
+	}
+
+	if _, err := Parse("svn", http.Header{}, []byte(`{}`)); err == nil {
+		t.Error("parsed an unknown provider")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	push := &Push{Urls: []string{"https://GitHub.com/org/repo", "", "git@github.com:org/repo.git"}}
+
+	for _, repoUrl := range []string{"https://github.com/org/repo.git", "ssh://git@github.com/org/repo", "git@github.com:org/repo", "https://github.com/org/repo/"} {
+		if !push.Matches(repoUrl) {
+			t.Errorf("%s does not match", repoUrl)
+		}
+	}
+
+	for _, repoUrl := range []string{"https://github.com/org/other", "https://gitlab.com/org/repo", ""} {
+		if push.Matches(repoUrl) {
+			t.Errorf("%s matches", repoUrl)
+		}
+	}
+}
+
+func TestUpdates(t *testing.T) {
+	tests := []struct {
+		push *Push
+		ref  string
+		want bool
+	}{
+		{&Push{Branch: "main", DefaultBranch: "main"}, "", true},
+		{&Push{Branch: "develop", DefaultBranch: "main"}, "", false},
+		{&Push{Branch: "develop"}, "", true},
+		{&Push{Tag: "v1"}, "", false},
+		{&Push{Tag: "v1"}, "v1", true},
+		{&Push{Branch: "develop"}, "develop", true},
+		{&Push{Branch: "develop"}, "main", false},
+	}
+
+	for _, test := range tests {
+		if got := test.push.Updates(test.ref); got != test.want {
+			t.Errorf("%+v updates %q: got %t, want %t", test.push, test.ref, got, test.want)
+		}
+	}
+}
+
+func TestDebouncer(t *testing.T) {
+	debouncer := NewDebouncer(20 * time.Millisecond)
+	var count int32
+	done := make(chan struct{}, 2)
+
+	for i := 0; i < 5; i++ {
+		debouncer.Trigger(1, func() {
+			atomic.AddInt32(&count, 1)
+			done <- struct{}{}
+		})
+	}
+
+	debouncer.Trigger(2, func() { done <- struct{}{} })
+
+	<-done
+	<-done
+	time.Sleep(40 * time.Millisecond)
+
+	if count := atomic.LoadInt32(&count); count != 1 {
+		t.Errorf("ran %d times, want once", count)
+	}
+}
@@ -0,0 +1,148 @@
+package reposerver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// COLLECT_GRACE_PERIOD spares recently created checkouts, whose repo may have
+// been added after the list of repos was sent.
+const COLLECT_GRACE_PERIOD = time.Minute
+
+// checkoutName matches the checkouts keyed by repo id, {repoId} or
+// {repoId}@{ref}.
+var checkoutName = regexp.MustCompile(`^([0-9]+)(@.*)?$`)
+
+// CollectCheckouts moves the checkouts of a previous layout, named after the
+// owner and name of the repo, to the directory of the listed repo with the
+// same url. Checkouts of repos which are not listed any more are removed.
+func (s RepoService) CollectCheckouts(_ context.Context, request *CollectRequest) (*CollectResponse, error) {
+	repoIds := map[string]bool{}
+
+	for _, repo := range request.Repos {
+		if !repoIdPattern.MatchString(repo.RepoId) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid repo id %q", repo.RepoId)
+		}
+
+		repoIds[repo.RepoId] = true
+	}
+
+	entries, err := os.ReadDir(s.repoRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := CollectResponse{}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		dir := filepath.Join(s.repoRoot, name)
+
+		if match := checkoutName.FindStringSubmatch(name); match != nil {
+			if repoIds[match[1]] || recentlyModified(entry) {
+				continue
+			}
+
+			err = s.removeCheckout(dir)
+
+			if err != nil {
+				return nil, err
+			}
+
+			response.Removed = append(response.Removed, name)
+			continue
+		}
+
+		r, err := git.PlainOpen(dir)
+
+		if err != nil {
+			continue
+		}
+
+		moved, err := s.migrateCheckout(r, dir, request.Repos)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if moved != "" {
+			response.Moved = append(response.Moved, name+" -> "+moved)
+		} else {
+			response.Removed = append(response.Removed, name)
+		}
+	}
+
+	return &response, nil
+}
+
+// migrateCheckout moves a legacy checkout to the directory of the first repo
+// with its url, keeping the ref it was pinned to. Checkouts no repo takes are
+// removed.
+func (s RepoService) migrateCheckout(r *git.Repository, dir string, repos []*CheckoutRepo) (string, error) {
+	url := originUrl(r)
+	_, suffix, pinned := strings.Cut(filepath.Base(dir), "@")
+
+	for _, repo := range repos {
+		if repo.Repo != url {
+			continue
+		}
+
+		name := repo.RepoId
+
+		if pinned {
+			name += "@" + suffix
+		}
+
+		target := filepath.Join(filepath.Dir(dir), name)
+		unlock := s.locks.exclusive(target)
+
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			log.Infof("moving checkout %s to %s", dir, target)
+			err = os.Rename(dir, target)
+			unlock()
+			return name, err
+		}
+
+		unlock()
+		break
+	}
+
+	return "", s.removeCheckout(dir)
+}
+
+func (s RepoService) removeCheckout(dir string) error {
+	defer s.locks.exclusive(dir)()
+
+	log.Infof("removing checkout %s", dir)
+	return os.RemoveAll(dir)
+}
+
+func recentlyModified(entry os.DirEntry) bool {
+	info, err := entry.Info()
+	return err != nil || time.Since(info.ModTime()) < COLLECT_GRACE_PERIOD
+}
+
+// originUrl is the url a checkout was cloned from.
+func originUrl(r *git.Repository) string {
+	remote, err := r.Remote("origin")
+
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return ""
+	}
+
+	return remote.Config().URLs[0]
+}
@@ -0,0 +1,101 @@
+package reposerver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCollectCheckouts(t *testing.T) {
+	root := t.TempDir()
+	s := RepoService{repoRoot: root, locks: newCheckoutLocks(), progress: newProgressHub()}
+	checkout := func(name string, url string, age time.Duration) {
+		dir := filepath.Join(root, name)
+		runGit(t, root, "init", "-q", dir)
+
+		if url != "" {
+			runGit(t, dir, "remote", "add", "origin", url)
+		}
+
+		modified := time.Now().Add(-age)
+		os.Chtimes(dir, modified, modified)
+	}
+	old := 2 * COLLECT_GRACE_PERIOD
+
+	// Legacy checkouts named after the owner and name of their repo.
+	checkout("org_app", "https://git.example.com/org/app.git", old)
+	checkout("org_app@v1", "https://git.example.com/org/app.git", old)
+	checkout("org_gone", "https://git.example.com/org/gone.git", old)
+	checkout("org_taken", "https://git.example.com/org/taken.git", old)
+	// Checkouts keyed by repo id.
+	checkout("2", "https://git.example.com/org/taken.git", old)
+	checkout("2@main", "https://git.example.com/org/taken.git", old)
+	checkout("3", "https://git.example.com/org/deleted.git", old)
+	checkout("4", "https://git.example.com/org/new.git", 0)
+	// Directories which are not checkouts.
+	os.Mkdir(filepath.Join(root, "notes"), 0755)
+	os.WriteFile(filepath.Join(root, "secrets.enc"), []byte("sealed"), 0600)
+
+	response, err := s.CollectCheckouts(context.Background(), &CollectRequest{Repos: []*CheckoutRepo{
+		{RepoId: "1", Repo: "https://git.example.com/org/app.git"},
+		{RepoId: "2", Repo: "https://git.example.com/org/taken.git"},
+	}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(response.Moved)
+	sort.Strings(response.Removed)
+	wantMoved := []string{"org_app -> 1", "org_app@v1 -> 1@v1"}
+	wantRemoved := []string{"3", "org_gone", "org_taken"}
+
+	if !reflect.DeepEqual(response.Moved, wantMoved) {
+		t.Errorf("moved %v, want %v", response.Moved, wantMoved)
+	}
+
+	if !reflect.DeepEqual(response.Removed, wantRemoved) {
+		t.Errorf("removed %v, want %v", response.Removed, wantRemoved)
+	}
+
+	entries, err := os.ReadDir(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	want := []string{"1", "1@v1", "2", "2@main", "4", "notes", "secrets.enc"}
+
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("left %v, want %v", names, want)
+	}
+
+	if len(s.locks.locks) != 0 {
+		t.Fatalf("%d checkout locks left", len(s.locks.locks))
+	}
+}
+
+func TestCollectCheckoutsInvalidRepoId(t *testing.T) {
+	root := t.TempDir()
+	s := RepoService{repoRoot: root, locks: newCheckoutLocks(), progress: newProgressHub()}
+	os.Mkdir(filepath.Join(root, "1"), 0755)
+	_, err := s.CollectCheckouts(context.Background(), &CollectRequest{Repos: []*CheckoutRepo{{RepoId: "../1"}}})
+
+	if err == nil {
+		t.Fatal("collected with an invalid repo id")
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "1")); err != nil {
+		t.Fatal("checkout removed by a rejected request")
+	}
+}
@@ -0,0 +1,278 @@
+package reposerver
+
+import (
+	"strconv"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CONFIG_SECTION is the section of the git config of a checkout recording the
+// clone options it was cloned with.
+const CONFIG_SECTION = "selfservice"
+
+// cloneOptions limit what is fetched and checked out of large repos: the
+// depth of the history, only the branch or tag which is checked out, and only
+// the directories of the applications of the repo. Partial clones, which
+// fetch blobs on demand, are not supported by go-git. Submodules are checked
+// out and LFS pointers resolved only when enabled. Signed checkouts only
+// check out commits signed by one of the trusted keys.
+type cloneOptions struct {
+	depth        int
+	singleBranch bool
+	sparse       bool
+	sparsePaths  []string
+	submodules   bool
+	lfs          bool
+	signed       bool
+	trustedKeys  []trustedKey
+}
+
+func syncOptions(request *SyncRequest) cloneOptions {
+	return cloneOptions{
+		depth:        int(request.Depth),
+		singleBranch: request.SingleBranch,
+		sparse:       request.Sparse,
+		sparsePaths:  request.SparsePaths,
+		submodules:   request.Submodules,
+		lfs:          request.Lfs,
+		signed:       request.RequireSignature,
+	}
+}
+
+// repoOptions are the options checkouts created to serve reads are cloned
+// with. The directories of sparse checkouts are set by their next sync.
+func repoOptions(options *RepoOptions) cloneOptions {
+	return cloneOptions{
+		depth:        int(options.GetDepth()),
+		singleBranch: options.GetSingleBranch(),
+		sparse:       options.GetSparse(),
+		submodules:   options.GetSubmodules(),
+		lfs:          options.GetLfs(),
+		signed:       options.GetRequireSignature(),
+	}
+}
+
+// noCheckout is set for checkouts which are checked out after cloning or
+// fetching, rather than by the clone or a pull.
+func (o cloneOptions) noCheckout() bool {
+	return o.sparse || o.signed
+}
+
+// trust loads the trusted keys of checkouts requiring signed commits.
+func (o *cloneOptions) trust() error {
+	if !o.signed {
+		return nil
+	}
+
+	keys, err := readTrustedKeys()
+
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return status.Error(codes.FailedPrecondition, "signed commits are required but no keys are trusted")
+	}
+
+	o.trustedKeys = keys
+	return nil
+}
+
+// verify refuses to check out commits which are not signed by a trusted key,
+// when signed commits are required.
+func (o cloneOptions) verify(r *git.Repository, hash plumbing.Hash) error {
+	if !o.signed {
+		return nil
+	}
+
+	commit, err := r.CommitObject(hash)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = verifyCommit(commit, o.trustedKeys)
+	return err
+}
+
+// sparseDirs are the paths checked out of sparse checkouts, nil meaning all.
+// Descriptors at the root of the repo are always checked out.
+func (o cloneOptions) sparseDirs() []string {
+	if !o.sparse {
+		return nil
+	}
+
+	dirs := append([]string{}, descriptorFiles...)
+
+	for _, sparsePath := range o.sparsePaths {
+		dir, err := RepoPath(sparsePath)
+
+		if err != nil {
+			continue
+		}
+
+		if dir == "" {
+			return nil
+		}
+
+		dirs = append(dirs, dir+"/")
+	}
+
+	return dirs
+}
+
+// unskipSparse clears the entries go-git skipped in the index of a sparse
+// checkout. It only ever marks them, so directories added to the checkout
+// would not be checked out.
+func (o cloneOptions) unskipSparse(r *git.Repository) error {
+	if !o.sparse {
+		return nil
+	}
+
+	idx, err := r.Storer.Index()
+
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range idx.Entries {
+		entry.SkipWorktree = false
+	}
+
+	return r.Storer.SetIndex(idx)
+}
+
+// recordOptions stores the options a checkout was synced with. Checkouts are
+// cloned again when the depth, single branch or sparse options change, as
+// they cannot be undone in place.
+func recordOptions(r *git.Repository, o cloneOptions) error {
+	cfg, err := r.Config()
+
+	if err != nil {
+		return err
+	}
+
+	section := cfg.Raw.Section(CONFIG_SECTION)
+	section.SetOption("depth", strconv.Itoa(o.depth))
+	section.SetOption("singleBranch", strconv.FormatBool(o.singleBranch))
+	section.SetOption("sparse", strconv.FormatBool(o.sparse))
+	section.SetOption("submodules", strconv.FormatBool(o.submodules))
+	section.SetOption("lfs", strconv.FormatBool(o.lfs))
+	return r.SetConfig(cfg)
+}
+
+// recordedOptions are the options a checkout was last synced with.
+func recordedOptions(r *git.Repository) (cloneOptions, error) {
+	cfg, err := r.Config()
+
+	if err != nil {
+		return cloneOptions{}, err
+	}
+
+	section := cfg.Raw.Section(CONFIG_SECTION)
+	o := cloneOptions{}
+	o.depth, _ = strconv.Atoi(section.Option("depth"))
+	o.singleBranch, _ = strconv.ParseBool(section.Option("singleBranch"))
+	o.sparse, _ = strconv.ParseBool(section.Option("sparse"))
+	o.submodules, _ = strconv.ParseBool(section.Option("submodules"))
+	o.lfs, _ = strconv.ParseBool(section.Option("lfs"))
+	return o, nil
+}
+
+func optionsChanged(r *git.Repository, o cloneOptions) bool {
+	recorded, err := recordedOptions(r)
+
+	if err != nil {
+		return true
+	}
+
+	return recorded.depth != o.depth || recorded.singleBranch != o.singleBranch || recorded.sparse != o.sparse
+}
+
+// settingsChanged reports whether the submodules or LFS options changed,
+// which are applied by the next sync of the checkout without cloning it again.
+func settingsChanged(r *git.Repository, o cloneOptions) bool {
+	recorded, err := recordedOptions(r)
+
+	if err != nil {
+		return true
+	}
+
+	return recorded.submodules != o.submodules || recorded.lfs != o.lfs
+}
+
+// syncedOptions are the options of a read request, or the options its
+// checkout was synced with when the request has none.
+func syncedOptions(r *git.Repository, options *RepoOptions) cloneOptions {
+	if options != nil || r == nil {
+		return repoOptions(options)
+	}
+
+	recorded, _ := recordedOptions(r)
+	return recorded
+}
+
+// cloneReference finds the branch or tag a ref names on the remote, or the
+// branch of the remote HEAD, so only it is cloned. Refs naming a commit are
+// not found, and are cloned with all branches and their full history as the
+// commit may be anywhere in it.
+func cloneReference(repoUrl string, auth transport.AuthMethod, ref string) (plumbing.ReferenceName, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{repoUrl}})
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+
+	if err != nil {
+		return "", err
+	}
+
+	if ref == "" {
+		return headBranch(refs), nil
+	}
+
+	return listedReference(refs, ref), nil
+}
+
+// listedReference finds the branch or tag a ref names among the refs listed
+// by a remote.
+func listedReference(refs []*plumbing.Reference, ref string) plumbing.ReferenceName {
+	for _, name := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)} {
+		for _, remoteRef := range refs {
+			if remoteRef.Name() == name {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+func headBranch(refs []*plumbing.Reference) plumbing.ReferenceName {
+	var head *plumbing.Reference
+
+	for _, remoteRef := range refs {
+		if remoteRef.Name() == plumbing.HEAD {
+			head = remoteRef
+		}
+	}
+
+	if head == nil {
+		return ""
+	}
+
+	if head.Type() == plumbing.SymbolicReference {
+		return head.Target()
+	}
+
+	for _, remoteRef := range refs {
+		if remoteRef.Name().IsBranch() && remoteRef.Hash() == head.Hash() {
+			return remoteRef.Name()
+		}
+	}
+
+	return ""
+}
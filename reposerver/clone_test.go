@@ -0,0 +1,254 @@
+package reposerver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSparseDirs(t *testing.T) {
+	tests := []struct {
+		name    string
+		options cloneOptions
+		want    []string
+	}{
+		{name: "not sparse", options: cloneOptions{sparsePaths: []string{"apps/backup"}}},
+		{name: "sparse", options: cloneOptions{sparse: true, sparsePaths: []string{"apps/backup", "/apps/cache/", "../outside"}}, want: append(append([]string{}, descriptorFiles...), "apps/backup/", "apps/cache/")},
+		{name: "root", options: cloneOptions{sparse: true, sparsePaths: []string{"apps/backup", "/"}}},
+	}
+
+	for _, test := range tests {
+		if got := test.options.sparseDirs(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSyncAndRepoOptions(t *testing.T) {
+	request := &SyncRequest{Depth: 5, SingleBranch: true, Sparse: true, SparsePaths: []string{"apps"}, Lfs: true, RequireSignature: true}
+	want := cloneOptions{depth: 5, singleBranch: true, sparse: true, sparsePaths: []string{"apps"}, lfs: true, signed: true}
+
+	if got := syncOptions(request); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if !want.noCheckout() || (cloneOptions{}).noCheckout() {
+		t.Error("sparse and signed checkouts are checked out after cloning")
+	}
+
+	if got := repoOptions(nil); !reflect.DeepEqual(got, cloneOptions{}) {
+		t.Errorf("got %+v for no options", got)
+	}
+
+	got := repoOptions(&RepoOptions{Depth: 5, SingleBranch: true, Sparse: true, Lfs: true, RequireSignature: true})
+	want.sparsePaths = nil
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestOptionsChanged(t *testing.T) {
+	r, err := git.PlainInit(t.TempDir(), false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := cloneOptions{depth: 10, singleBranch: true, sparse: true, submodules: true}
+
+	if err := recordOptions(r, recorded); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		options  cloneOptions
+		changed  bool
+		settings bool
+	}{
+		{name: "same", options: recorded},
+		{name: "sparse paths and signatures", options: cloneOptions{depth: 10, singleBranch: true, sparse: true, submodules: true, sparsePaths: []string{"apps"}, signed: true}},
+		{name: "depth", options: cloneOptions{depth: 0, singleBranch: true, sparse: true, submodules: true}, changed: true},
+		{name: "single branch", options: cloneOptions{depth: 10, sparse: true, submodules: true}, changed: true},
+		{name: "sparse", options: cloneOptions{depth: 10, singleBranch: true, submodules: true}, changed: true},
+		{name: "submodules", options: cloneOptions{depth: 10, singleBranch: true, sparse: true}, settings: true},
+		{name: "lfs", options: cloneOptions{depth: 10, singleBranch: true, sparse: true, submodules: true, lfs: true}, settings: true},
+	}
+
+	for _, test := range tests {
+		if got := optionsChanged(r, test.options); got != test.changed {
+			t.Errorf("%s: options changed %t, want %t", test.name, got, test.changed)
+		}
+
+		if got := settingsChanged(r, test.options); got != test.settings {
+			t.Errorf("%s: settings changed %t, want %t", test.name, got, test.settings)
+		}
+	}
+
+	if got := syncedOptions(r, nil); !reflect.DeepEqual(got, recorded) {
+		t.Errorf("got synced options %+v, want %+v", got, recorded)
+	}
+}
+
+func commitFile(t *testing.T, work string, name string, content string) {
+	t.Helper()
+	os.MkdirAll(filepath.Dir(filepath.Join(work, name)), 0755)
+	os.WriteFile(filepath.Join(work, name), []byte(content), 0644)
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", name)
+	runGit(t, work, "push", "-q", filepath.Join(filepath.Dir(work), "public.git"), "main")
+}
+
+func TestUpdateSparseCheckout(t *testing.T) {
+	serverUrl, work := gitHttpServer(t)
+	commitFile(t, work, "apps/backup/schema.json", `{"type":"object"}`)
+	commitFile(t, work, "apps/cache/schema.json", `{"type":"object"}`)
+	s := RepoService{repoRoot: t.TempDir(), secrets: memorySecretStore{}, locks: newCheckoutLocks(), progress: newProgressHub()}
+	request := &SyncRequest{Repo: serverUrl + "/public.git", RepoId: "1", Sparse: true, SparsePaths: []string{"apps/backup"}}
+
+	repoDir := checkoutDir(s.repoRoot, "1", "")
+
+	// Sync refuses the hosts of test servers.
+	if err := s.syncCheckout(request.Repo, "1", repoDir, "", syncOptions(request)); err != nil {
+		t.Fatal(err)
+	}
+
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(repoDir, name))
+		return err == nil
+	}
+
+	if !exists("apps/backup/schema.json") || exists("apps/cache/schema.json") || exists("schema.json") {
+		t.Fatal("the sparse checkout does not hold only the application directory")
+	}
+
+	request.SparsePaths = []string{"apps/backup", "apps/cache"}
+
+	if _, err := s.UpdateSparseCheckout(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	if !exists("apps/cache/schema.json") {
+		t.Fatal("the added application directory was not checked out")
+	}
+
+	// Checkouts with other options wait for their next sync.
+	request.SparsePaths = []string{"apps/backup"}
+	request.Depth = 1
+
+	if _, err := s.UpdateSparseCheckout(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	if !exists("apps/cache/schema.json") {
+		t.Fatal("a checkout with other options was updated")
+	}
+
+	if _, err := s.UpdateSparseCheckout(context.Background(), &SyncRequest{RepoId: "2", Sparse: true}); err != nil {
+		t.Fatalf("checkout which was not cloned yet: %s", err)
+	}
+
+	if _, err := s.UpdateSparseCheckout(context.Background(), &SyncRequest{RepoId: "../1"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("got %v for an invalid repo id", err)
+	}
+}
+
+// githubUrl serves the public repo of the git http server under a github.com
+// url, as reads refuse the hosts of test servers.
+func githubUrl(t *testing.T, serverUrl string, work string) string {
+	target, err := url.Parse(serverUrl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Symlink(".", filepath.Join(filepath.Dir(work), "org"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dialer := &net.Dialer{}
+	transport := &http.Transport{DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, target.Host)
+	}}
+	client.InstallProtocol("http", githttp.NewClient(&http.Client{Transport: transport}))
+	t.Cleanup(func() { client.InstallProtocol("http", githttp.DefaultClient) })
+	return "http://github.com/org/public.git"
+}
+
+func TestCommitsAtShallow(t *testing.T) {
+	serverUrl, work := gitHttpServer(t)
+	first, err := git.PlainOpen(work)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := first.Head()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitFile(t, work, "data.json", `{"size": 1}`)
+	commitFile(t, work, "data.json", `{"size": 2}`)
+	s := RepoService{repoRoot: t.TempDir(), secrets: memorySecretStore{}, locks: newCheckoutLocks(), progress: newProgressHub()}
+	repoUrl := githubUrl(t, serverUrl, work)
+
+	if err := s.syncCheckout(repoUrl, "1", checkoutDir(s.repoRoot, "1", ""), "", cloneOptions{depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	options := &RepoOptions{Depth: 1}
+	commits, release, err := s.commitsAt(repoUrl, "1", "", options, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release()
+
+	if !strings.HasPrefix(commits[0].Message, "data.json") {
+		t.Errorf("got commit %q", commits[0].Message)
+	}
+
+	_, _, err = s.commitsAt(repoUrl, "1", "", options, head.Hash().String())
+
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "shallow") {
+		t.Fatalf("got %v for a commit beyond the depth", err)
+	}
+
+	// Commits pushed since the sync are fetched.
+	commitFile(t, work, "data.json", `{"size": 3}`)
+	latest, err := first.Head()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits, release, err = s.commitsAt(repoUrl, "1", "", options, latest.Hash().String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release()
+
+	if commits[0].Hash != latest.Hash() {
+		t.Errorf("got commit %s, want %s", commits[0].Hash, latest.Hash())
+	}
+}
@@ -0,0 +1,309 @@
+package reposerver
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MAX_FILE_SIZE = 1 << 20
+	DOCS_DIR      = "docs"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	sanitize = bluemonday.UGCPolicy()
+)
+
+// RepoPath cleans a path relative to the root of a repo, a leading slash
+// denoting the root. Paths escaping the repo are refused.
+func RepoPath(p string) (string, error) {
+	if strings.ContainsRune(p, 0) || strings.Contains(p, "\\") {
+		return "", status.Errorf(codes.InvalidArgument, "invalid path %q", p)
+	}
+
+	cleaned := path.Clean(strings.TrimLeft(p, "/"))
+
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", status.Errorf(codes.InvalidArgument, "path %s escapes the repository", p)
+	}
+
+	if cleaned == "." {
+		return "", nil
+	}
+
+	return cleaned, nil
+}
+
+func subtree(tree *object.Tree, dir string) (*object.Tree, error) {
+	if dir != "" {
+		return tree.Tree(dir)
+	}
+
+	return tree, nil
+}
+
+// contentCommit resolves the commit content is read from. Branches and tags
+// other than the ref of the checkout are fetched into it, and must be listed
+// by the remote.
+func (s RepoService) contentCommit(request *ContentRequest) (*checkoutCommit, func(), error) {
+	hash := request.Hash
+
+	if hash == "" && request.RemoteRef != "" && request.RemoteRef != request.Ref {
+		var err error
+		hash, err = s.fetchRemoteRef(request.Repo, request.RepoId, request.Ref, request.Options, request.RemoteRef)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return s.commitAt(request.Repo, request.RepoId, request.Ref, request.Options, hash)
+}
+
+func (s RepoService) GetTree(_ context.Context, request *ContentRequest) (*TreeResponse, error) {
+	commit, release, err := s.contentCommit(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	dir, err := RepoPath(request.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.dirTree(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := TreeResponse{Hash: commit.Hash.String(), Path: dir}
+
+	for _, entry := range tree.Entries {
+		treeEntry := TreeEntry{Name: entry.Name, Path: path.Join(dir, entry.Name), Type: "blob"}
+
+		switch entry.Mode {
+		case filemode.Dir:
+			treeEntry.Type = "tree"
+		case filemode.Submodule:
+			treeEntry.Type = "commit"
+		default:
+			size, err := tree.Size(entry.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			treeEntry.Size = size
+		}
+
+		response.Entries = append(response.Entries, &treeEntry)
+	}
+
+	return &response, nil
+}
+
+func (s RepoService) GetFile(_ context.Context, request *ContentRequest) (*FileResponse, error) {
+	commit, release, err := s.contentCommit(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	filePath, err := RepoPath(request.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, size, err := readBlob(commit, filePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileResponse{
+		Hash:    commit.Hash.String(),
+		Path:    filePath,
+		Size:    size,
+		Content: content,
+	}, nil
+}
+
+// GetDocs renders the README.md and docs/*.md of an application directory to
+// sanitized HTML.
+func (s RepoService) GetDocs(_ context.Context, request *ContentRequest) (*DocsResponse, error) {
+	commit, release, err := s.contentCommit(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	dir, err := RepoPath(request.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.dirTree(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var docPaths []string
+
+	for _, entry := range tree.Entries {
+		if entry.Mode.IsFile() && strings.EqualFold(entry.Name, "README.md") {
+			docPaths = append(docPaths, path.Join(dir, entry.Name))
+		}
+	}
+
+	if docs, err := tree.Tree(DOCS_DIR); err == nil {
+		var names []string
+
+		for _, entry := range docs.Entries {
+			if entry.Mode.IsFile() && strings.EqualFold(path.Ext(entry.Name), ".md") {
+				names = append(names, entry.Name)
+			}
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			docPaths = append(docPaths, path.Join(dir, DOCS_DIR, name))
+		}
+	}
+
+	response := DocsResponse{Hash: commit.Hash.String()}
+
+	for _, docPath := range docPaths {
+		content, _, err := readBlob(commit, docPath)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var html bytes.Buffer
+		err = markdown.Convert(content, &html)
+
+		if err != nil {
+			return nil, err
+		}
+
+		response.Docs = append(response.Docs, &Doc{
+			Path: docPath,
+			Html: sanitize.Sanitize(html.String()),
+		})
+	}
+
+	return &response, nil
+}
+
+// readBlob reads a regular file of a commit, refusing files larger than
+// MAX_FILE_SIZE. Files in submodules are read from the submodule, and LFS
+// pointers are replaced by the content they point to.
+func readBlob(commit *checkoutCommit, filePath string) ([]byte, int64, error) {
+	owner, file, err := findFile(commit, filePath)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Symlinks are followed within the repository only.
+	for links := 0; file.Mode == filemode.Symlink; links++ {
+		if links >= MAX_SYMLINKS {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "%s: too many levels of symlinks", filePath)
+		}
+
+		target, err := file.Contents()
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if path.IsAbs(target) {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "symlink %s escapes the repository", filePath)
+		}
+
+		linked, err := RepoPath(path.Join(path.Dir(filePath), target))
+
+		if err != nil {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "symlink %s escapes the repository", filePath)
+		}
+
+		filePath = linked
+		owner, file, err = findFile(commit, filePath)
+
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if !file.Mode.IsFile() {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "%s is not a regular file", filePath)
+	}
+
+	if file.Size > MAX_FILE_SIZE {
+		return nil, file.Size, status.Errorf(codes.FailedPrecondition, "%s is larger than %d bytes", filePath, MAX_FILE_SIZE)
+	}
+
+	reader, err := file.Reader()
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if pointer, ok := parseLfsPointer(content); ok {
+		content, err = owner.lfs.read(filePath, pointer)
+		return content, pointer.Size, err
+	}
+
+	return content, file.Size, nil
+}
+
+// findFile finds a file of a commit, or of the submodule it is in.
+func findFile(commit *checkoutCommit, filePath string) (*checkoutCommit, *object.File, error) {
+	owner, rel, err := commit.locate(filePath)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := owner.File(rel)
+
+	if err != nil {
+		return nil, nil, status.Errorf(codes.NotFound, "file %s not found at %s", filePath, commit.Hash)
+	}
+
+	return owner, file, nil
+}
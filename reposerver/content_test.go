@@ -0,0 +1,143 @@
+package reposerver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRepoPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"", "", true},
+		{"/", "", true},
+		{".", "", true},
+		{"app", "app", true},
+		{"/app/data.json", "app/data.json", true},
+		{"app//nested/../data.json", "app/data.json", true},
+		{"app/..", "", true},
+		{"..", "", false},
+		{"../app", "", false},
+		{"/../app", "", false},
+		{"app/../../etc/passwd", "", false},
+		{"//etc/passwd", "etc/passwd", true},
+		{"app\x00.json", "", false},
+		{"app\\..\\..\\etc", "", false},
+		{"..\\etc", "", false},
+	}
+
+	for _, test := range tests {
+		got, err := RepoPath(test.path)
+
+		if test.ok && (err != nil || got != test.want) {
+			t.Errorf("RepoPath(%q) = %q, %v, want %q", test.path, got, err, test.want)
+		}
+
+		if !test.ok && status.Code(err) != codes.InvalidArgument {
+			t.Errorf("RepoPath(%q) = %q, %v, want it refused", test.path, got, err)
+		}
+	}
+}
+
+func TestConfinePath(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := filepath.Join(base, "repo")
+	os.MkdirAll(filepath.Join(root, "app"), 0755)
+	os.WriteFile(filepath.Join(root, "app", "data.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(base, "secret.json"), []byte(`{}`), 0644)
+	os.Symlink("data.json", filepath.Join(root, "app", "link.json"))
+	os.Symlink("../app", filepath.Join(root, "app", "self"))
+	os.Symlink("../../secret.json", filepath.Join(root, "app", "outside.json"))
+	os.Symlink(filepath.Join(base, "secret.json"), filepath.Join(root, "app", "absolute.json"))
+	os.Symlink("loop-b", filepath.Join(root, "app", "loop-a"))
+	os.Symlink("loop-a", filepath.Join(root, "app", "loop-b"))
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"app/data.json", "app/data.json"},
+		{"/app/data.json", "app/data.json"},
+		{"app/link.json", "app/data.json"},
+		{"app/self/self/data.json", "app/data.json"},
+		{"../secret.json", ""},
+		{"app/outside.json", ""},
+		{"app/absolute.json", ""},
+		{"app/loop-a", ""},
+		{"app/missing.json", ""},
+		{"app\x00/data.json", ""},
+		{"app\\data.json", ""},
+	}
+
+	for _, test := range tests {
+		got, err := confinePath(root, test.path)
+
+		if test.want != "" && (err != nil || got != filepath.Join(root, filepath.FromSlash(test.want))) {
+			t.Errorf("confinePath(%q) = %q, %v, want %s", test.path, got, err, test.want)
+		}
+
+		if test.want == "" && err == nil {
+			t.Errorf("confinePath(%q) = %q, want it refused", test.path, got)
+		}
+	}
+}
+
+func TestReadBlobSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte(`{"a":1}`), 0644)
+	os.Symlink("data.json", filepath.Join(dir, "app", "link.json"))
+	os.Symlink("link.json", filepath.Join(dir, "app", "chain.json"))
+	os.Symlink("../../etc/passwd", filepath.Join(dir, "app", "outside.json"))
+	os.Symlink("/etc/passwd", filepath.Join(dir, "app", "absolute.json"))
+	os.Symlink("loop-b", filepath.Join(dir, "app", "loop-a"))
+	os.Symlink("loop-a", filepath.Join(dir, "app", "loop-b"))
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "symlinks")
+
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &checkoutCommit{Commit: commit, gitDir: filepath.Join(dir, git.GitDirName), lfs: &lfsStore{}}
+
+	for _, p := range []string{"app/data.json", "app/link.json", "app/chain.json"} {
+		content, _, err := readBlob(c, p)
+
+		if err != nil || string(content) != `{"a":1}` {
+			t.Errorf("readBlob(%s) = %s, %v", p, content, err)
+		}
+	}
+
+	for _, p := range []string{"app/outside.json", "app/absolute.json", "app/loop-a"} {
+		if content, _, err := readBlob(c, p); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("readBlob(%s) = %s, %v, want it refused", p, content, err)
+		}
+	}
+
+	if _, _, err := readBlob(c, "app/missing.json"); status.Code(err) != codes.NotFound {
+		t.Errorf("readBlob of a missing file, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package reposerver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CREDENTIAL_HELPERS lists the git credential helpers repos may use, as
+	// a comma separated list of names. Helpers run on the reposerver, so they
+	// are picked by the operator rather than by API callers.
+	CREDENTIAL_HELPERS        = "CREDENTIAL_HELPERS"
+	CREDENTIAL_HELPER_TIMEOUT = 30 * time.Second
+)
+
+var helperName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// allowedHelper checks a credential helper is one of CREDENTIAL_HELPERS.
+func allowedHelper(name string) error {
+	if !helperName.MatchString(name) {
+		return status.Errorf(codes.InvalidArgument, "invalid credential helper %q", name)
+	}
+
+	for _, allowed := range strings.Split(os.Getenv(CREDENTIAL_HELPERS), ",") {
+		if strings.TrimSpace(allowed) == name {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.InvalidArgument, "credential helper %s is not enabled, see %s", name, CREDENTIAL_HELPERS)
+}
+
+// helperAuth asks a git credential helper for the username and password of an
+// http or https url, running git credential-<name> get like git does.
+func helperAuth(name string, repoUrl string) (*http.BasicAuth, error) {
+	err := allowedHelper(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := transport.NewEndpoint(repoUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Protocol != "http" && endpoint.Protocol != "https" {
+		return nil, errors.Errorf("credential helpers are only supported for http and https repos, not %s", endpoint.Protocol)
+	}
+
+	host := endpoint.Host
+
+	if endpoint.Port != 0 && !(endpoint.Protocol == "http" && endpoint.Port == 80) && !(endpoint.Protocol == "https" && endpoint.Port == 443) {
+		host = fmt.Sprintf("%s:%d", host, endpoint.Port)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), CREDENTIAL_HELPER_TIMEOUT)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "credential-"+name, "get")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\npath=%s\n\n", endpoint.Protocol, host, strings.TrimPrefix(endpoint.Path, "/")))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "credential helper %s: %s", name, strings.TrimSpace(stderr.String()))
+	}
+
+	auth := &http.BasicAuth{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+
+		switch key {
+		case "username":
+			auth.Username = value
+		case "password":
+			auth.Password = value
+		}
+	}
+
+	if auth.Password == "" {
+		return nil, errors.Errorf("credential helper %s returned no password for %s", name, host)
+	}
+
+	if auth.Username == "" {
+		auth.Username = "git"
+	}
+
+	return auth, nil
+}
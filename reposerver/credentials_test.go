@@ -0,0 +1,195 @@
+package reposerver
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type memorySecretStore map[string]Credentials
+
+func (m memorySecretStore) Name() string {
+	return "memory"
+}
+
+func (m memorySecretStore) Get(repoId string) (*Credentials, error) {
+	credentials, ok := m[repoId]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &credentials, nil
+}
+
+func (m memorySecretStore) Put(repoId string, credentials Credentials) error {
+	m[repoId] = credentials
+	return nil
+}
+
+func (m memorySecretStore) Delete(repoId string) error {
+	delete(m, repoId)
+	return nil
+}
+
+func (m memorySecretStore) List() ([]string, error) {
+	var repoIds []string
+
+	for repoId := range m {
+		repoIds = append(repoIds, repoId)
+	}
+
+	return repoIds, nil
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		t.Fatalf("git %s: %s\n%s", strings.Join(args, " "), err, output)
+	}
+}
+
+// gitHttpServer serves the bare repos public.git and private.git with git
+// http-backend, private.git requiring the basic auth user alice:s3cret. The
+// work tree they were cloned from is returned too.
+func gitHttpServer(t *testing.T) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	execPath, err := exec.Command("git", "--exec-path").Output()
+
+	if err != nil {
+		t.Skip("git --exec-path failed")
+	}
+
+	backend := filepath.Join(strings.TrimSpace(string(execPath)), "git-http-backend")
+
+	if _, err := os.Stat(backend); err != nil {
+		t.Skip("git-http-backend is not installed")
+	}
+
+	root := t.TempDir()
+	work := filepath.Join(root, "work")
+	runGit(t, root, "init", "-q", "-b", "main", work)
+	os.WriteFile(filepath.Join(work, "schema.json"), []byte(`{"type":"object"}`), 0644)
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "initial")
+
+	for _, name := range []string{"public.git", "private.git"} {
+		runGit(t, root, "clone", "-q", "--bare", work, filepath.Join(root, name))
+	}
+
+	handler := &cgi.Handler{
+		Path: backend,
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/private.git") {
+			username, password, ok := r.BasicAuth()
+
+			if !ok || username != "alice" || password != "s3cret" {
+				rw.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+				http.Error(rw, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		handler.ServeHTTP(rw, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, work
+}
+
+func TestCloneAnonymousHttp(t *testing.T) {
+	serverUrl, _ := gitHttpServer(t)
+	_, err := cloneRepo(memorySecretStore{}, "1", serverUrl+"/public.git", t.TempDir(), "", cloneOptions{}, nil)
+
+	if err != nil {
+		t.Fatalf("anonymous clone: %s", err)
+	}
+}
+
+func TestCloneBasicAuth(t *testing.T) {
+	serverUrl, _ := gitHttpServer(t)
+	secrets := memorySecretStore{}
+	_, err := cloneRepo(secrets, "1", serverUrl+"/private.git", t.TempDir(), "", cloneOptions{}, nil)
+
+	if err == nil {
+		t.Fatal("clone without credentials succeeded")
+	}
+
+	secrets["1"] = Credentials{Method: AUTH_BASIC, Username: "alice", Password: "s3cret"}
+	_, err = cloneRepo(secrets, "1", serverUrl+"/private.git", t.TempDir(), "", cloneOptions{}, nil)
+
+	if err != nil {
+		t.Fatalf("clone with credentials: %s", err)
+	}
+}
+
+func TestCloneCredentialHelper(t *testing.T) {
+	serverUrl, _ := gitHttpServer(t)
+	bin := t.TempDir()
+	helper := "#!/bin/sh\ncat >/dev/null\necho username=alice\necho password=s3cret\n"
+	err := os.WriteFile(filepath.Join(bin, "git-credential-fixture"), []byte(helper), 0755)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	secrets := memorySecretStore{"1": {Method: AUTH_HELPER, Helper: "fixture"}}
+
+	t.Setenv(CREDENTIAL_HELPERS, "store")
+	_, err = cloneRepo(secrets, "1", serverUrl+"/private.git", t.TempDir(), "", cloneOptions{}, nil)
+
+	if err == nil {
+		t.Fatal("clone with a helper which is not enabled succeeded")
+	}
+
+	t.Setenv(CREDENTIAL_HELPERS, "store,fixture")
+	_, err = cloneRepo(secrets, "1", serverUrl+"/private.git", t.TempDir(), "", cloneOptions{}, nil)
+
+	if err != nil {
+		t.Fatalf("clone with credential helper: %s", err)
+	}
+}
+
+func TestReuseAuthSshHost(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	secrets := memorySecretStore{"1": {Method: AUTH_SSH, PrivateKey: privateKey}}
+	repoUrl := "ssh://git@git.example.com/org/repo.git"
+	auth, err := reuseAuth(secrets, "1", repoUrl, "ssh://git@git.example.com/org/module.git")
+
+	if err != nil || auth == nil {
+		t.Fatalf("ssh key not reused for the host of the repo: %v", err)
+	}
+
+	auth, err = reuseAuth(secrets, "1", repoUrl, "ssh://git@other.example.com/org/module.git")
+
+	if err == nil || auth != nil {
+		t.Fatal("ssh key reused for another host")
+	}
+}
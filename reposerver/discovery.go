@@ -0,0 +1,152 @@
+package reposerver
+
+import (
+	"io"
+	"path"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	DESCRIPTOR_API_VERSION = "selfservice/v1"
+	DESCRIPTOR_KIND        = "Application"
+)
+
+var (
+	descriptorFiles = []string{"selfservice.yaml", "selfservice.yml"}
+	// genericDescriptorFiles are common names used by other tools, which are
+	// only descriptors when they declare the apiVersion and kind of one.
+	genericDescriptorFiles = []string{"app.yaml", "app.yml"}
+)
+
+// typeMeta identifies the kind of a YAML file.
+type typeMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// descriptor is an application declared in a selfservice.yaml or app.yaml.
+// The manifest path is relative to the directory of the descriptor, which is
+// also the default.
+type descriptor struct {
+	typeMeta     `json:",inline"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Icon         string   `json:"icon"`
+	Owners       []string `json:"owners"`
+	Namespace    string   `json:"namespace"`
+	ManifestPath string   `json:"manifest_path"`
+}
+
+func isDescriptor(name string) bool {
+	return contains(descriptorFiles, name) || contains(genericDescriptorFiles, name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// discoverApplications finds the application descriptors of a commit. Invalid
+// descriptors are returned with their error, so the application they
+// declared is kept as is until they are fixed. app.yaml files of other tools
+// are skipped.
+func discoverApplications(commit *object.Commit) ([]*AppDescriptor, error) {
+	files, err := commit.Files()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var descriptors []*AppDescriptor
+
+	err = files.ForEach(func(file *object.File) error {
+		if !file.Mode.IsFile() || !isDescriptor(path.Base(file.Name)) {
+			return nil
+		}
+
+		appDescriptor, err := parseDescriptor(file)
+
+		if err != nil {
+			appDescriptor = &AppDescriptor{Error: err.Error()}
+		}
+
+		if appDescriptor == nil {
+			return nil
+		}
+
+		appDescriptor.Source = file.Name
+		descriptors = append(descriptors, appDescriptor)
+		return nil
+	})
+
+	return descriptors, err
+}
+
+func parseDescriptor(file *object.File) (*AppDescriptor, error) {
+	if file.Size > MAX_FILE_SIZE {
+		return nil, errors.Errorf("%s is larger than %d bytes", file.Name, MAX_FILE_SIZE)
+	}
+
+	reader, err := file.Reader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	contents, err := io.ReadAll(reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var meta typeMeta
+	generic := contains(genericDescriptorFiles, path.Base(file.Name))
+
+	if err := yaml.Unmarshal(contents, &meta); err != nil && generic {
+		return nil, nil
+	}
+
+	if generic && (meta.APIVersion != DESCRIPTOR_API_VERSION || meta.Kind != DESCRIPTOR_KIND) {
+		return nil, nil
+	}
+
+	var d descriptor
+	err = yaml.UnmarshalStrict(contents, &d)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid descriptor %s", file.Name)
+	}
+
+	if (d.APIVersion != "" && d.APIVersion != DESCRIPTOR_API_VERSION) || (d.Kind != "" && d.Kind != DESCRIPTOR_KIND) {
+		return nil, errors.Errorf("descriptor %s must have apiVersion %s and kind %s", file.Name, DESCRIPTOR_API_VERSION, DESCRIPTOR_KIND)
+	}
+
+	if d.Name == "" {
+		return nil, errors.Errorf("descriptor %s has no name", file.Name)
+	}
+
+	manifestPath, err := RepoPath(path.Join(path.Dir(file.Name), d.ManifestPath))
+
+	if err != nil {
+		return nil, errors.Errorf("descriptor %s has a manifest_path which escapes the repository", file.Name)
+	}
+
+	return &AppDescriptor{
+		Name:         d.Name,
+		Description:  d.Description,
+		Icon:         d.Icon,
+		Owners:       d.Owners,
+		Namespace:    d.Namespace,
+		ManifestPath: manifestPath,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package reposerver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestDiscoverApplications(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	files := map[string]string{
+		"forms/selfservice.yaml": "name: form\nmanifest_path: manifests\n",
+		"engine/app.yaml":        "runtime: go119\nhandlers: []\n",
+		"jobs/app.yml":           "apiVersion: selfservice/v1\nkind: Application\nname: job\n",
+		"broken/selfservice.yml": "name: broken\nunknown: field\n",
+	}
+
+	for name, content := range files {
+		os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	descriptors, err := discoverApplications(commit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]*AppDescriptor{}
+
+	for _, d := range descriptors {
+		found[d.Source] = d
+	}
+
+	if len(found) != 3 {
+		t.Fatalf("got descriptors %v", descriptors)
+	}
+
+	if d := found["forms/selfservice.yaml"]; d == nil || d.Name != "form" || d.ManifestPath != "forms/manifests" {
+		t.Fatalf("got %v", d)
+	}
+
+	if d := found["jobs/app.yml"]; d == nil || d.Name != "job" || d.Error != "" {
+		t.Fatalf("got %v", d)
+	}
+
+	if d := found["broken/selfservice.yml"]; d == nil || d.Error == "" {
+		t.Fatalf("got %v", d)
+	}
+}
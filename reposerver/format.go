@@ -0,0 +1,697 @@
+package reposerver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yosuke-furukawa/json5/encoding/json5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MAX_REF_DEPTH = 32
+	MAX_SYMLINKS  = 40
+
+	// TEMPLATES_DIR holds the template files of an application, next to its
+	// manifests.
+	TEMPLATES_DIR = "templates"
+)
+
+var (
+	yamlLine       = regexp.MustCompile(`^line (\d+): (.*)$`)
+	unsafeDefChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+	// manifestKinds are the manifests of a form, read from data.*, schema.*
+	// and uischema.* files.
+	manifestKinds = []string{"data", "schema", "uischema"}
+	// manifestExts are the supported formats, in order of precedence when a
+	// manifest exists in several of them.
+	manifestExts = []string{".json", ".yaml", ".yml", ".json5"}
+)
+
+// readFunc reads a file of a repo by its path relative to the repo root.
+type readFunc func(filePath string) ([]byte, error)
+
+// dirReader lists the files of a directory of a working tree, and reads files
+// relative to the root of the working tree.
+func dirReader(root string, dir string) (map[string]bool, readFunc, error) {
+	root, err := filepath.EvalSymlinks(root)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dirPath, err := confinePath(root, dir)
+
+	if os.IsNotExist(err) {
+		return nil, nil, status.Errorf(codes.NotFound, "%s not found", dir)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files, err := os.ReadDir(dirPath)
+
+	if err != nil {
+		return nil, nil, status.Errorf(codes.NotFound, "%s not found", dir)
+	}
+
+	names := map[string]bool{}
+
+	for _, file := range files {
+		if file.Type().IsRegular() || file.Type()&os.ModeSymlink != 0 {
+			names[file.Name()] = true
+		}
+	}
+
+	return names, func(filePath string) ([]byte, error) {
+		resolved, err := confinePath(root, filePath)
+
+		if os.IsNotExist(err) {
+			return nil, status.Errorf(codes.NotFound, "file %s not found", filePath)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return os.ReadFile(resolved)
+	}, nil
+}
+
+// confinePath resolves a path relative to root, a directory without symlinks,
+// following symlinks and refusing paths which end up outside of root.
+func confinePath(root string, p string) (string, error) {
+	rel, err := RepoPath(p)
+
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(rel)))
+
+	if err != nil {
+		return "", err
+	}
+
+	within, err := filepath.Rel(root, resolved)
+
+	if err != nil || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) {
+		return "", status.Errorf(codes.InvalidArgument, "path %s escapes the repository", p)
+	}
+
+	return resolved, nil
+}
+
+// manifestFile picks the file of a manifest kind among the files of a
+// directory, following the precedence of manifestExts.
+func manifestFile(names map[string]bool, kind string) string {
+	for _, ext := range manifestExts {
+		if names[kind+ext] {
+			return kind + ext
+		}
+	}
+
+	return ""
+}
+
+// loadManifests reads the data, schema and uischema manifests of a directory.
+// References of the schema to other files of the repo are inlined.
+func loadManifests(manifestResp *ManifestsResponse, dir string, names map[string]bool, read readFunc) error {
+	for _, kind := range manifestKinds {
+		name := manifestFile(names, kind)
+
+		if name == "" {
+			continue
+		}
+
+		filePath := path.Join(dir, name)
+		content, err := read(filePath)
+
+		if err != nil {
+			return err
+		}
+
+		doc, err := parseManifest(filePath, content)
+
+		if err != nil {
+			return err
+		}
+
+		if kind == "schema" {
+			resolver := refResolver{read: read, root: filePath, files: map[string]interface{}{filePath: doc}}
+			resolved, err := resolver.resolve(filePath, doc, false)
+
+			if err != nil {
+				return err
+			}
+
+			var ok bool
+			doc, ok = resolved.(map[string]interface{})
+
+			if !ok {
+				return manifestErrorf(filePath, 1, "schema must be an object")
+			}
+
+			err = resolver.define(doc)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		details, err := structpb.NewStruct(doc)
+
+		if err != nil {
+			return manifestErrorf(filePath, 0, "%v", err)
+		}
+
+		switch kind {
+		case "data":
+			manifestResp.Data = details
+		case "schema":
+			manifestResp.Schema = details
+		case "uischema":
+			manifestResp.UiSchema = details
+		}
+	}
+
+	return nil
+}
+
+// parseManifest parses a JSON, YAML or JSON5 manifest, which must be an
+// object. The documents of a multi-document YAML file are merged in order.
+func parseManifest(filePath string, content []byte) (map[string]interface{}, error) {
+	doc, err := parseDocument(filePath, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := normalize(doc).(map[string]interface{})
+
+	if !ok {
+		return nil, manifestErrorf(filePath, 1, "manifest must be an object")
+	}
+
+	return object, nil
+}
+
+func parseDocument(filePath string, content []byte) (interface{}, error) {
+	var doc interface{}
+
+	switch path.Ext(filePath) {
+	case ".json":
+		err := json.Unmarshal(content, &doc)
+
+		if err != nil {
+			return nil, syntaxError(filePath, content, err)
+		}
+
+		return doc, nil
+	case ".json5":
+		err := json5.Unmarshal(content, &doc)
+
+		if err != nil {
+			return nil, syntaxError(filePath, content, err)
+		}
+
+		return doc, nil
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+		for {
+			var next interface{}
+			err := decoder.Decode(&next)
+
+			if err == io.EOF {
+				return doc, nil
+			}
+
+			if err != nil {
+				return nil, yamlError(filePath, err)
+			}
+
+			// Documents with non-string keys are objects too once normalized.
+			doc = merge(doc, normalize(next))
+		}
+	default:
+		return nil, manifestErrorf(filePath, 0, "unsupported manifest format")
+	}
+}
+
+// ManifestError is a manifest which cannot be parsed, reported with the file
+// and, when known, the line it occurred at.
+type ManifestError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *ManifestError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s: line %d: %s", e.File, e.Line, e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", e.File, e.Message)
+}
+
+// GRPCStatus returns manifest errors to clients as InvalidArgument.
+func (e *ManifestError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+func manifestErrorf(filePath string, line int, format string, args ...interface{}) error {
+	return &ManifestError{File: filePath, Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+// yamlError moves the line of a YAML error out of its message. Of the
+// errors decoding a document, the first is reported.
+func yamlError(filePath string, err error) error {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+
+	if typeErr, ok := err.(*yaml.TypeError); ok && len(typeErr.Errors) > 0 {
+		message = typeErr.Errors[0]
+	}
+	match := yamlLine.FindStringSubmatch(message)
+
+	if match == nil {
+		return manifestErrorf(filePath, 0, "%s", message)
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	return manifestErrorf(filePath, line, "%s", match[2])
+}
+
+// syntaxError reports a JSON or JSON5 error with the line it occurred at.
+func syntaxError(filePath string, content []byte, err error) error {
+	offset := int64(-1)
+
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+	case *json5.SyntaxError:
+		offset = e.Offset
+	}
+
+	if offset < 0 {
+		return manifestErrorf(filePath, 0, "%v", err)
+	}
+
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+
+	line := bytes.Count(content[:offset], []byte("\n")) + 1
+	return manifestErrorf(filePath, line, "%v", err)
+}
+
+// merge deep merges the objects of multi-document YAML files, later
+// documents taking precedence.
+func merge(dst interface{}, src interface{}) interface{} {
+	dstObject, ok := dst.(map[string]interface{})
+
+	if !ok {
+		return src
+	}
+
+	srcObject, ok := src.(map[string]interface{})
+
+	if !ok {
+		return src
+	}
+
+	for key, value := range srcObject {
+		dstObject[key] = merge(dstObject[key], value)
+	}
+
+	return dstObject
+}
+
+// normalize converts the values decoded from YAML which have no JSON
+// equivalent, namely non-string keys and timestamps.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = normalize(child)
+		}
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+
+		for key, child := range v {
+			object[fmt.Sprint(key)] = normalize(child)
+		}
+
+		return object
+	case []interface{}:
+		for i, child := range v {
+			v[i] = normalize(child)
+		}
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+
+	return value
+}
+
+// refResolver inlines the $refs of a schema to other files of the repo, such
+// as shared definitions. Refs local to the schema and remote refs are left to
+// the form renderer. Recursive refs are defined once under $defs of the
+// schema and referenced from there.
+type refResolver struct {
+	read  readFunc
+	root  string
+	files map[string]interface{}
+	stack []string
+	// defs names the recursive targets, pending until they are defined.
+	defs    map[string]string
+	pending []string
+}
+
+// resolve walks a node of a file, replacing its file refs by their target.
+// Local refs are inlined too once outside the schema itself, since they would
+// no longer point to the same document.
+func (r *refResolver) resolve(filePath string, node interface{}, external bool) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			target, pointer, ok, err := r.target(filePath, ref, external)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if ok && target == r.root {
+				return r.withRef(filePath, v, "#"+pointer, external)
+			}
+
+			if ok {
+				return r.follow(filePath, ref, target, pointer, v, external)
+			}
+		}
+
+		for key, child := range v {
+			resolved, err := r.resolve(filePath, child, external)
+
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = resolved
+		}
+	case []interface{}:
+		for i, child := range v {
+			resolved, err := r.resolve(filePath, child, external)
+
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = resolved
+		}
+	}
+
+	return node, nil
+}
+
+// withRef replaces the $ref of a node by a ref local to the schema.
+func (r *refResolver) withRef(filePath string, node map[string]interface{}, ref string, external bool) (interface{}, error) {
+	replaced := map[string]interface{}{"$ref": ref}
+
+	for k, v := range node {
+		if k != "$ref" {
+			resolved, err := r.resolve(filePath, v, external)
+
+			if err != nil {
+				return nil, err
+			}
+
+			replaced[k] = resolved
+		}
+	}
+
+	return replaced, nil
+}
+
+// define adds the recursive targets met while resolving to $defs of the
+// schema, resolving each of them once.
+func (r *refResolver) define(schema map[string]interface{}) error {
+	if len(r.pending) == 0 {
+		return nil
+	}
+
+	defs, ok := schema["$defs"].(map[string]interface{})
+
+	if !ok && schema["$defs"] != nil {
+		return manifestErrorf(r.root, 0, "$defs must be an object")
+	}
+
+	if defs == nil {
+		defs = map[string]interface{}{}
+		schema["$defs"] = defs
+	}
+
+	for len(r.pending) > 0 {
+		key := r.pending[0]
+		r.pending = r.pending[1:]
+		target, pointer, _ := strings.Cut(key, "#")
+		doc, err := r.load(target)
+
+		if err != nil {
+			return err
+		}
+
+		value, err := lookupPointer(doc, pointer)
+
+		if err != nil {
+			return err
+		}
+
+		r.stack = []string{key}
+		resolved, err := r.resolve(target, deepCopy(value), true)
+		r.stack = nil
+
+		if err != nil {
+			return err
+		}
+
+		defs[r.defs[key]] = resolved
+	}
+
+	return nil
+}
+
+// defName names the definition of a recursive target after its file and
+// pointer.
+func (r *refResolver) defName(key string) string {
+	if name, ok := r.defs[key]; ok {
+		return name
+	}
+
+	if r.defs == nil {
+		r.defs = map[string]string{}
+	}
+
+	base := unsafeDefChars.ReplaceAllString(strings.TrimSuffix(key, "#"), "_")
+	name := base
+
+	for i := 2; r.definedAs(name); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	r.defs[key] = name
+	r.pending = append(r.pending, key)
+	return name
+}
+
+func (r *refResolver) definedAs(name string) bool {
+	for _, defined := range r.defs {
+		if defined == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *refResolver) target(filePath string, ref string, external bool) (string, string, bool, error) {
+	refPath, pointer, _ := strings.Cut(ref, "#")
+
+	if strings.Contains(refPath, ":") {
+		return "", "", false, nil
+	}
+
+	if refPath == "" {
+		return filePath, pointer, external, nil
+	}
+
+	target, err := RepoPath(path.Join(path.Dir(filePath), refPath))
+
+	if err != nil {
+		return "", "", false, manifestErrorf(filePath, 0, "$ref %s escapes the repository", ref)
+	}
+
+	return target, pointer, true, nil
+}
+
+func (r *refResolver) follow(filePath string, ref string, target string, pointer string, node map[string]interface{}, external bool) (interface{}, error) {
+	key := target + "#" + pointer
+
+	for _, visiting := range r.stack {
+		if visiting == key {
+			return r.withRef(filePath, node, "#/$defs/"+r.defName(key), external)
+		}
+	}
+
+	if len(r.stack) >= MAX_REF_DEPTH {
+		return nil, manifestErrorf(filePath, 0, "$ref %s nested more than %d times", ref, MAX_REF_DEPTH)
+	}
+
+	doc, err := r.load(target)
+
+	if status.Code(err) == codes.NotFound {
+		return nil, manifestErrorf(filePath, 0, "$ref %s: %s not found", ref, target)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := lookupPointer(doc, pointer)
+
+	if err != nil {
+		return nil, manifestErrorf(filePath, 0, "$ref %s: %v", ref, err)
+	}
+
+	r.stack = append(r.stack, key)
+	value, err = r.resolve(target, deepCopy(value), true)
+	r.stack = r.stack[:len(r.stack)-1]
+
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := value.(map[string]interface{})
+
+	if !ok || len(node) == 1 {
+		return value, nil
+	}
+
+	// Keywords next to the $ref, such as a title, override the target.
+	merged := make(map[string]interface{}, len(object)+len(node))
+
+	for k, v := range object {
+		merged[k] = v
+	}
+
+	for k, v := range node {
+		if k != "$ref" {
+			resolved, err := r.resolve(filePath, v, external)
+
+			if err != nil {
+				return nil, err
+			}
+
+			merged[k] = resolved
+		}
+	}
+
+	return merged, nil
+}
+
+func (r *refResolver) load(filePath string) (interface{}, error) {
+	if doc, ok := r.files[filePath]; ok {
+		return doc, nil
+	}
+
+	content, err := r.read(filePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parseDocument(filePath, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc = normalize(doc)
+	r.files[filePath] = doc
+	return doc, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+
+		for key, child := range v {
+			object[key] = deepCopy(child)
+		}
+
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(v))
+
+		for i, child := range v {
+			array[i] = deepCopy(child)
+		}
+
+		return array
+	}
+
+	return value
+}
+
+// lookupPointer resolves a JSON pointer such as /definitions/address.
+func lookupPointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+
+	value := doc
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+
+			if !ok {
+				return nil, fmt.Errorf("%s not found", pointer)
+			}
+
+			value = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("%s not found", pointer)
+			}
+
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("%s not found", pointer)
+		}
+	}
+
+	return value, nil
+}
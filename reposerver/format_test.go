@@ -0,0 +1,221 @@
+package reposerver
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func mapReader(files map[string]string) readFunc {
+	return func(filePath string) ([]byte, error) {
+		content, ok := files[filePath]
+
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "%s not found", filePath)
+		}
+
+		return []byte(content), nil
+	}
+}
+
+func loadSchema(t *testing.T, files map[string]string) map[string]interface{} {
+	t.Helper()
+	var manifests ManifestsResponse
+	err := loadManifests(&manifests, "app", map[string]bool{"schema.json": true}, mapReader(files))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return manifests.Schema.AsMap()
+}
+
+func TestRecursiveExternalRefs(t *testing.T) {
+	schema := loadSchema(t, map[string]string{
+		"app/schema.json": `{"properties": {"root": {"$ref": "../defs/tree.json#/definitions/node", "title": "Root"}}}`,
+		"defs/tree.json":  `{"definitions": {"node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/definitions/node"}}}}}}`,
+	})
+	content, _ := json.Marshal(schema)
+	expected := `{"$defs":{"defs_tree.json_definitions_node":{"properties":{"children":{"items":{"$ref":"#/$defs/defs_tree.json_definitions_node"},"type":"array"}},"type":"object"}},` +
+		`"properties":{"root":{"properties":{"children":{"items":{"$ref":"#/$defs/defs_tree.json_definitions_node"},"type":"array"}},"title":"Root","type":"object"}}}`
+
+	if string(content) != expected {
+		t.Fatalf("got %s", content)
+	}
+}
+
+func TestMutuallyRecursiveFiles(t *testing.T) {
+	schema := loadSchema(t, map[string]string{
+		"app/schema.json": `{"$ref": "a.json"}`,
+		"app/a.json":      `{"type": "object", "properties": {"b": {"$ref": "b.json"}}}`,
+		"app/b.json":      `{"type": "object", "properties": {"a": {"$ref": "a.json"}, "root": {"$ref": "schema.json"}}}`,
+	})
+	content, _ := json.Marshal(schema)
+	expected := `{"$defs":{"app_a.json":{"properties":{"b":{"properties":{"a":{"$ref":"#/$defs/app_a.json"},"root":{"$ref":"#"}},"type":"object"}},"type":"object"}},` +
+		`"properties":{"b":{"properties":{"a":{"$ref":"#/$defs/app_a.json"},"root":{"$ref":"#"}},"type":"object"}},"type":"object"}`
+
+	if string(content) != expected {
+		t.Fatalf("got %s", content)
+	}
+}
+
+func TestSharedExternalRefs(t *testing.T) {
+	schema := loadSchema(t, map[string]string{
+		"app/schema.json": `{"properties": {"home": {"$ref": "address.json"}, "work": {"$ref": "address.json"}}}`,
+		"app/address.json": `{"type": "object", "properties": {"country": {"$ref": "#/definitions/country"}},
+			"definitions": {"country": {"type": "string"}}}`,
+	})
+
+	if _, ok := schema["$defs"]; ok {
+		t.Fatalf("non recursive refs were defined: %v", schema)
+	}
+
+	properties := schema["properties"].(map[string]interface{})
+
+	for _, name := range []string{"home", "work"} {
+		country := properties[name].(map[string]interface{})["properties"].(map[string]interface{})["country"]
+
+		if country.(map[string]interface{})["type"] != "string" {
+			t.Fatalf("%s: got %v", name, country)
+		}
+	}
+}
+
+func TestManifestFile(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{names: []string{"schema.json5", "schema.yml", "schema.yaml", "schema.json"}, want: "schema.json"},
+		{names: []string{"schema.json5", "schema.yml", "schema.yaml"}, want: "schema.yaml"},
+		{names: []string{"schema.json5", "schema.yml"}, want: "schema.yml"},
+		{names: []string{"schema.json5", "data.json"}, want: "schema.json5"},
+		{names: []string{"schema.toml", "uischema.json"}, want: ""},
+	}
+
+	for _, test := range tests {
+		names := map[string]bool{}
+
+		for _, name := range test.names {
+			names[name] = true
+		}
+
+		if got := manifestFile(names, "schema"); got != test.want {
+			t.Errorf("%v: got %q, want %q", test.names, got, test.want)
+		}
+	}
+}
+
+func TestLoadManifestsFormats(t *testing.T) {
+	files := map[string]string{
+		"app/schema.json":    `{"type": "object"}`,
+		"app/schema.yaml":    "type: array\n",
+		"app/data.yml":       "size: 1\nzone: eu\n",
+		"app/uischema.json5": "{\n  // comment\n  type: \"VerticalLayout\",\n  elements: [],\n}",
+	}
+	names := map[string]bool{"schema.json": true, "schema.yaml": true, "data.yml": true, "uischema.json5": true}
+	var manifests ManifestsResponse
+
+	if err := loadManifests(&manifests, "app", names, mapReader(files)); err != nil {
+		t.Fatal(err)
+	}
+
+	if manifests.Schema.AsMap()["type"] != "object" {
+		t.Errorf("the JSON schema does not take precedence: %v", manifests.Schema.AsMap())
+	}
+
+	if data := manifests.Data.AsMap(); data["size"] != float64(1) || data["zone"] != "eu" {
+		t.Errorf("got data %v", data)
+	}
+
+	if uischema := manifests.UiSchema.AsMap(); uischema["type"] != "VerticalLayout" {
+		t.Errorf("got uischema %v", uischema)
+	}
+}
+
+func TestParseManifestYamlDocuments(t *testing.T) {
+	content := `size: 1
+labels:
+  team: platform
+  tier: web
+zones: [a, b]
+---
+labels:
+  tier: api
+zones: [c]
+created: 2024-01-02T03:04:05Z
+---
+1: one
+`
+	doc, err := parseManifest("app/data.yaml", []byte(content))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"size":    1,
+		"labels":  map[string]interface{}{"team": "platform", "tier": "api"},
+		"zones":   []interface{}{"c"},
+		"created": "2024-01-02T03:04:05Z",
+		"1":       "one",
+	}
+
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("got %v, want %v", doc, want)
+	}
+
+	if _, err := parseManifest("app/data.yaml", []byte("size: 1\n---\n- a\n")); err == nil {
+		t.Error("a list replacing the object of an earlier document was accepted")
+	}
+}
+
+func TestManifestErrors(t *testing.T) {
+	tests := []struct {
+		file    string
+		content string
+		line    int
+	}{
+		{file: "app/schema.json", content: "{\n  \"type\": \"object\",\n  \"properties\": {,}\n}", line: 3},
+		{file: "app/schema.json", content: "{\n  \"type\": \"object\"\n", line: 3},
+		{file: "app/data.json", content: "[1, 2]", line: 1},
+		{file: "app/data.json5", content: "{\n  size: 1,\n  zones: [1,, 2]\n}", line: 3},
+		{file: "app/data.yaml", content: "size: 1\nzone: a\nzone: b\n", line: 3},
+		{file: "app/data.yaml", content: "- a\n", line: 1},
+		{file: "app/data.toml", content: "size = 1", line: 0},
+	}
+
+	for _, test := range tests {
+		_, err := parseManifest(test.file, []byte(test.content))
+		var manifestErr *ManifestError
+
+		if !errors.As(err, &manifestErr) {
+			t.Errorf("%s %q: got %v", test.file, test.content, err)
+			continue
+		}
+
+		if manifestErr.File != test.file || manifestErr.Line != test.line {
+			t.Errorf("%s %q: got %s, want line %d", test.file, test.content, err, test.line)
+		}
+
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: got code %s", test.file, status.Code(err))
+		}
+	}
+
+	err := manifestErrorf("app/data.yaml", 4, "did not find expected key")
+
+	if err.Error() != "app/data.yaml: line 4: did not find expected key" {
+		t.Errorf("got %q", err.Error())
+	}
+
+	err = manifestErrorf("app/data.toml", 0, "unsupported manifest format")
+
+	if err.Error() != "app/data.toml: unsupported manifest format" {
+		t.Errorf("got %q", err.Error())
+	}
+}
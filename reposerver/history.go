@@ -0,0 +1,562 @@
+package reposerver
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	HISTORY_LIMIT     = 50
+	MAX_HISTORY_LIMIT = 500
+
+	// MAX_HISTORY_WALK limits the commits looked at for the history of a
+	// path, which may not have changed for a long time.
+	MAX_HISTORY_WALK = 10000
+
+	BREAKING_REQUIRED_REMOVED = "required-field-removed"
+	BREAKING_REQUIRED_ADDED   = "field-now-required"
+	BREAKING_ENUM_NARROWED    = "enum-narrowed"
+	BREAKING_TYPE_CHANGED     = "type-changed"
+)
+
+// BreakingRules describes the schema changes flagged as breaking, as data
+// submitted with the previous schema may no longer validate.
+var BreakingRules = map[string]string{
+	BREAKING_REQUIRED_REMOVED: "A required field was removed from the schema",
+	BREAKING_REQUIRED_ADDED:   "A field without a default became required",
+	BREAKING_ENUM_NARROWED:    "Values were removed from the enum of a field, or an enum was added",
+	BREAKING_TYPE_CHANGED:     "A field no longer accepts a type it accepted",
+}
+
+func (s RepoService) GetHistory(_ context.Context, request *HistoryRequest) (*HistoryResponse, error) {
+	commit, release, err := s.commitAt(request.Repo, request.RepoId, request.Ref, request.Options, request.Hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	dir, err := RepoPath(request.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(request.Limit)
+
+	if limit <= 0 {
+		limit = HISTORY_LIMIT
+	}
+
+	if limit > MAX_HISTORY_LIMIT {
+		limit = MAX_HISTORY_LIMIT
+	}
+
+	owner, rel, err := commit.locate(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := pathHistory(owner.Commit, rel, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &HistoryResponse{Hash: commit.Hash.String(), Commits: commits}, nil
+}
+
+// pathHistory lists the commits changing a path, newest first, like git log
+// does. Commits which are the same as one of their parents at the path are
+// skipped, and only that parent is followed, so changes merged from other
+// branches are listed once by the merge. Parents missing from shallow
+// checkouts end the history.
+func pathHistory(start *object.Commit, p string, limit int) ([]*CommitInfo, error) {
+	var commits []*CommitInfo
+	queue := &commitQueue{start}
+	seen := map[plumbing.Hash]bool{start.Hash: true}
+
+	for walked := 0; queue.Len() > 0 && len(commits) < limit && walked < MAX_HISTORY_WALK; walked++ {
+		commit := heap.Pop(queue).(*object.Commit)
+		hash, err := pathHash(commit, p)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var parents []*object.Commit
+		var treesame *object.Commit
+		existed := false
+
+		for i := 0; i < commit.NumParents(); i++ {
+			parent, err := commit.Parent(i)
+
+			if err != nil {
+				continue
+			}
+
+			parentHash, err := pathHash(parent, p)
+
+			if err != nil {
+				return nil, err
+			}
+
+			existed = existed || !parentHash.IsZero()
+
+			if treesame == nil && parentHash == hash {
+				treesame = parent
+			}
+
+			parents = append(parents, parent)
+		}
+
+		if treesame != nil {
+			parents = []*object.Commit{treesame}
+		} else if existed || !hash.IsZero() {
+			commits = append(commits, commitInfo(commit))
+		}
+
+		for _, parent := range parents {
+			if !seen[parent.Hash] {
+				seen[parent.Hash] = true
+				heap.Push(queue, parent)
+			}
+		}
+	}
+
+	return commits, nil
+}
+
+// commitQueue is a heap of the commits left to walk, newest committed first.
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int {
+	return len(q)
+}
+
+func (q commitQueue) Less(i, j int) bool {
+	return q[i].Committer.When.After(q[j].Committer.When)
+}
+
+func (q commitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *commitQueue) Push(x interface{}) {
+	*q = append(*q, x.(*object.Commit))
+}
+
+func (q *commitQueue) Pop() interface{} {
+	old := *q
+	commit := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return commit
+}
+
+// pathHash is the hash of the tree or blob at a path of a commit, zero when
+// the path does not exist.
+func pathHash(commit *object.Commit, p string) (plumbing.Hash, error) {
+	tree, err := commit.Tree()
+
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if p == "" {
+		return tree.Hash, nil
+	}
+
+	entry, err := tree.FindEntry(p)
+
+	if err != nil {
+		return plumbing.ZeroHash, nil
+	}
+
+	return entry.Hash, nil
+}
+
+func commitInfo(commit *object.Commit) *CommitInfo {
+	return &CommitInfo{
+		Hash:    commit.Hash.String(),
+		Message: strings.TrimSpace(commit.Message),
+		Author:  commit.Author.Name,
+		Email:   commit.Author.Email,
+		Date:    commit.Author.When.Format(time.RFC3339),
+	}
+}
+
+func (s RepoService) DiffApplication(_ context.Context, request *DiffRequest) (*DiffResponse, error) {
+	if request.From == "" {
+		return nil, status.Error(codes.InvalidArgument, "from is required")
+	}
+
+	commits, release, err := s.commitsAt(request.Repo, request.RepoId, request.Ref, request.Options, request.From, request.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	dir, err := RepoPath(request.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := commits[0], commits[1]
+	files, err := diffDir(from, to, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffResponse{
+		From:     from.Hash.String(),
+		To:       to.Hash.String(),
+		Files:    files,
+		Breaking: breakingChanges(from, to, dir),
+	}, nil
+}
+
+// diffDir diffs the files of a directory between two commits. Directories
+// missing from one of them are diffed against an empty tree.
+func diffDir(from *checkoutCommit, to *checkoutCommit, dir string) ([]*FileDiff, error) {
+	fromTree, err := optionalDirTree(from, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toTree, err := optionalDirTree(to, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromTree == nil && toTree == nil {
+		return nil, status.Errorf(codes.NotFound, "directory %s not found at %s or %s", dir, from.Hash, to.Hash)
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*FileDiff
+
+	for _, change := range changes {
+		action, err := change.Action()
+
+		if err != nil {
+			return nil, err
+		}
+
+		name := change.To.Name
+
+		if action == merkletrie.Delete {
+			name = change.From.Name
+		}
+
+		patch, err := changePatch(change)
+
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, &FileDiff{
+			Path:   path.Join(dir, name),
+			Action: actionName(action),
+			Patch:  patch,
+		})
+	}
+
+	return files, nil
+}
+
+func optionalDirTree(commit *checkoutCommit, dir string) (*object.Tree, error) {
+	tree, err := commit.dirTree(dir)
+
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+
+	return tree, err
+}
+
+// changePatch is the unified diff of a change. Submodules are diffed by
+// commit like git does, files larger than MAX_FILE_SIZE are not diffed.
+func changePatch(change *object.Change) (string, error) {
+	if change.From.TreeEntry.Mode == filemode.Submodule || change.To.TreeEntry.Mode == filemode.Submodule {
+		var patch strings.Builder
+
+		if !change.From.TreeEntry.Hash.IsZero() {
+			fmt.Fprintf(&patch, "-Subproject commit %s\n", change.From.TreeEntry.Hash)
+		}
+
+		if !change.To.TreeEntry.Hash.IsZero() {
+			fmt.Fprintf(&patch, "+Subproject commit %s\n", change.To.TreeEntry.Hash)
+		}
+
+		return patch.String(), nil
+	}
+
+	fromFile, toFile, err := change.Files()
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range []*object.File{fromFile, toFile} {
+		if file != nil && file.Size > MAX_FILE_SIZE {
+			return "", nil
+		}
+	}
+
+	patch, err := change.Patch()
+
+	if err != nil {
+		return "", err
+	}
+
+	return patch.String(), nil
+}
+
+func actionName(action merkletrie.Action) string {
+	switch action {
+	case merkletrie.Insert:
+		return "added"
+	case merkletrie.Delete:
+		return "deleted"
+	default:
+		return "modified"
+	}
+}
+
+// breakingChanges compares the schemas of a directory at two commits. Schemas
+// which cannot be loaded at the newer commit are reported as manifest errors.
+func breakingChanges(from *checkoutCommit, to *checkoutCommit, dir string) []*Finding {
+	fromSchema, _, err := commitSchema(from, dir)
+
+	if err != nil || fromSchema == nil {
+		return nil
+	}
+
+	toSchema, file, err := commitSchema(to, dir)
+
+	if err != nil {
+		return []*Finding{manifestFinding(dir, err)}
+	}
+
+	if toSchema == nil {
+		return nil
+	}
+
+	return schemaChanges(file, "", fromSchema, toSchema)
+}
+
+func commitSchema(commit *checkoutCommit, dir string) (map[string]interface{}, string, error) {
+	names, read, err := commitReader(commit, dir)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	var manifests ManifestsResponse
+	err = loadManifests(&manifests, dir, names, read)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if manifests.Schema == nil {
+		return nil, "", nil
+	}
+
+	return manifests.Schema.AsMap(), path.Join(dir, manifestFile(names, "schema")), nil
+}
+
+// schemaChanges compares the schema of a field and its properties and items
+// with the previous one.
+func schemaChanges(file string, field string, old map[string]interface{}, new map[string]interface{}) []*Finding {
+	var findings []*Finding
+	label := field
+
+	if label == "" {
+		label = "schema"
+	}
+
+	breaking := func(rule string, format string, args ...interface{}) {
+		findings = append(findings, &Finding{Rule: rule, Level: LEVEL_ERROR, File: file, Message: label + ": " + fmt.Sprintf(format, args...)})
+	}
+
+	if removed := removedTypes(schemaTypes(old), schemaTypes(new)); len(removed) > 0 {
+		breaking(BREAKING_TYPE_CHANGED, "type %s is no longer accepted", strings.Join(removed, ", "))
+	}
+
+	if newEnum, ok := new["enum"].([]interface{}); ok {
+		oldEnum, ok := old["enum"].([]interface{})
+
+		if !ok {
+			breaking(BREAKING_ENUM_NARROWED, "values are restricted to an enum")
+		} else if removed := removedValues(oldEnum, newEnum); len(removed) > 0 {
+			breaking(BREAKING_ENUM_NARROWED, "enum values %s were removed", strings.Join(removed, ", "))
+		}
+	}
+
+	oldProperties, _ := old["properties"].(map[string]interface{})
+	newProperties, _ := new["properties"].(map[string]interface{})
+	oldRequired := stringSet(old["required"])
+	newRequired := stringSet(new["required"])
+
+	for _, name := range sortedKeys(oldRequired) {
+		if _, ok := newProperties[name]; !ok && !newRequired[name] {
+			breaking(BREAKING_REQUIRED_REMOVED, "required field %s was removed", name)
+		}
+	}
+
+	for _, name := range sortedKeys(newRequired) {
+		property, _ := newProperties[name].(map[string]interface{})
+
+		if _, ok := property["default"]; !oldRequired[name] && !ok {
+			breaking(BREAKING_REQUIRED_ADDED, "field %s is now required", name)
+		}
+	}
+
+	names := make([]string, 0, len(newProperties))
+
+	for name := range newProperties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldProperty, ok := oldProperties[name].(map[string]interface{})
+		newProperty, ok2 := newProperties[name].(map[string]interface{})
+
+		if ok && ok2 {
+			findings = append(findings, schemaChanges(file, joinField(field, name), oldProperty, newProperty)...)
+		}
+	}
+
+	oldItems, ok := old["items"].(map[string]interface{})
+	newItems, ok2 := new["items"].(map[string]interface{})
+
+	if ok && ok2 {
+		findings = append(findings, schemaChanges(file, label+"[]", oldItems, newItems)...)
+	}
+
+	return findings
+}
+
+func joinField(field string, name string) string {
+	if field == "" {
+		return name
+	}
+
+	return field + "." + name
+}
+
+// schemaTypes are the types a schema accepts, nil meaning any. Restricting
+// untyped fields is not flagged, as their values usually had the new type.
+func schemaTypes(schema map[string]interface{}) map[string]bool {
+	switch v := schema["type"].(type) {
+	case string:
+		return map[string]bool{v: true}
+	case []interface{}:
+		return stringSet(v)
+	}
+
+	return nil
+}
+
+// removedTypes are the types no longer accepted, integers being accepted as
+// numbers.
+func removedTypes(old map[string]bool, new map[string]bool) []string {
+	if new == nil {
+		return nil
+	}
+
+	if old == nil {
+		return nil
+	}
+
+	var removed []string
+
+	for _, t := range sortedKeys(old) {
+		if !new[t] && !(t == "integer" && new["number"]) {
+			removed = append(removed, t)
+		}
+	}
+
+	return removed
+}
+
+func removedValues(old []interface{}, new []interface{}) []string {
+	values := map[string]bool{}
+
+	for _, value := range new {
+		values[jsonValue(value)] = true
+	}
+
+	var removed []string
+
+	for _, value := range old {
+		if !values[jsonValue(value)] {
+			removed = append(removed, jsonValue(value))
+		}
+	}
+
+	return removed
+}
+
+func jsonValue(value interface{}) string {
+	content, err := json.Marshal(value)
+
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(content)
+}
+
+func stringSet(value interface{}) map[string]bool {
+	values, _ := value.([]interface{})
+	set := map[string]bool{}
+
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			set[s] = true
+		}
+	}
+
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,286 @@
+package reposerver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPathHistoryFollowsTreesameParent(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte(`{}`), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "add app")
+
+	// A branch changing the app and changing it back is the same as main at
+	// the app when merged, so git log does not list its commits.
+	runGit(t, dir, "checkout", "-q", "-b", "side")
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte(`{"a":1}`), 0644)
+	runGit(t, dir, "commit", "-q", "-am", "side change")
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte(`{}`), 0644)
+	runGit(t, dir, "commit", "-q", "-am", "side revert")
+
+	runGit(t, dir, "checkout", "-q", "main")
+	os.WriteFile(filepath.Join(dir, "other.txt"), []byte("other"), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "main other")
+	runGit(t, dir, "merge", "-q", "--no-ff", "-m", "merge side", "side")
+
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := pathHistory(commit, "app", HISTORY_LIMIT)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(commits) != 1 || commits[0].Message != "add app" {
+		var messages []string
+
+		for _, c := range commits {
+			messages = append(messages, c.Message)
+		}
+
+		t.Fatalf("got history %q, want only the commit adding the app", messages)
+	}
+}
+
+func TestRemovedTypes(t *testing.T) {
+	tests := []struct {
+		old  map[string]bool
+		new  map[string]bool
+		want []string
+	}{
+		{old: map[string]bool{"string": true}, new: map[string]bool{"string": true, "null": true}},
+		{old: map[string]bool{"string": true, "null": true}, new: map[string]bool{"string": true}, want: []string{"null"}},
+		{old: map[string]bool{"integer": true}, new: map[string]bool{"number": true}},
+		{old: map[string]bool{"number": true}, new: map[string]bool{"integer": true}, want: []string{"number"}},
+		{old: nil, new: map[string]bool{"string": true}},
+		{old: map[string]bool{"string": true}, new: nil},
+	}
+
+	for _, test := range tests {
+		if got := removedTypes(test.old, test.new); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v to %v: got %v, want %v", test.old, test.new, got, test.want)
+		}
+	}
+}
+
+func TestRemovedValues(t *testing.T) {
+	tests := []struct {
+		old  string
+		new  string
+		want []string
+	}{
+		{old: `["s", "m"]`, new: `["s", "m", "l"]`},
+		{old: `["s", "m", "l"]`, new: `["l", "s"]`, want: []string{`"m"`}},
+		{old: `[1, "1", null]`, new: `["1"]`, want: []string{"1", "null"}},
+		{old: `[{"a": 1}]`, new: `[{"a": 1}]`},
+	}
+
+	for _, test := range tests {
+		var old, new []interface{}
+		json.Unmarshal([]byte(test.old), &old)
+		json.Unmarshal([]byte(test.new), &new)
+
+		if got := removedValues(old, new); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s to %s: got %v, want %v", test.old, test.new, got, test.want)
+		}
+	}
+}
+
+func TestSchemaChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{
+			name: "compatible",
+			old:  `{"type": "object", "required": ["size"], "properties": {"size": {"type": "integer", "enum": [1, 2]}}}`,
+			new:  `{"type": "object", "required": ["size"], "properties": {"size": {"type": "number", "enum": [1, 2, 3]}, "zone": {"type": "string"}}}`,
+		},
+		{
+			name: "type",
+			old:  `{"properties": {"size": {"type": ["string", "integer"]}}}`,
+			new:  `{"properties": {"size": {"type": "string"}}}`,
+			want: []string{"type-changed: size: type integer is no longer accepted"},
+		},
+		{
+			name: "untyped field",
+			old:  `{"properties": {"size": {}}}`,
+			new:  `{"properties": {"size": {"type": "string"}}}`,
+		},
+		{
+			name: "enum narrowed",
+			old:  `{"properties": {"size": {"enum": ["s", "m", "l"]}}}`,
+			new:  `{"properties": {"size": {"enum": ["s", "l"]}}}`,
+			want: []string{`enum-narrowed: size: enum values "m" were removed`},
+		},
+		{
+			name: "enum added",
+			old:  `{"properties": {"size": {"type": "string"}}}`,
+			new:  `{"properties": {"size": {"type": "string", "enum": ["s"]}}}`,
+			want: []string{"enum-narrowed: size: values are restricted to an enum"},
+		},
+		{
+			name: "required field removed",
+			old:  `{"required": ["size", "zone"], "properties": {"size": {}, "zone": {}}}`,
+			new:  `{"required": ["size"], "properties": {"size": {}}}`,
+			want: []string{"required-field-removed: schema: required field zone was removed"},
+		},
+		{
+			name: "required field made optional",
+			old:  `{"required": ["size", "zone"], "properties": {"size": {}, "zone": {}}}`,
+			new:  `{"required": ["size"], "properties": {"size": {}, "zone": {}}}`,
+		},
+		{
+			name: "field now required",
+			old:  `{"properties": {"size": {}, "zone": {}}}`,
+			new:  `{"required": ["size", "zone"], "properties": {"size": {"default": 1}, "zone": {}}}`,
+			want: []string{"field-now-required: schema: field zone is now required"},
+		},
+		{
+			name: "nested properties and items",
+			old:  `{"properties": {"disks": {"type": "array", "items": {"required": ["size"], "properties": {"size": {"type": "integer"}, "kind": {"enum": ["ssd", "hdd"]}}}}}}`,
+			new:  `{"properties": {"disks": {"type": "array", "items": {"properties": {"kind": {"enum": ["ssd"]}}}}}}`,
+			want: []string{
+				"required-field-removed: disks[]: required field size was removed",
+				`enum-narrowed: disks[].kind: enum values "hdd" were removed`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var old, new map[string]interface{}
+
+		if err := json.Unmarshal([]byte(test.old), &old); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		if err := json.Unmarshal([]byte(test.new), &new); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		var got []string
+
+		for _, finding := range schemaChanges("app/schema.json", "", old, new) {
+			if finding.File != "app/schema.json" || finding.Level != LEVEL_ERROR {
+				t.Errorf("%s: got finding %v", test.name, finding)
+			}
+
+			got = append(got, finding.Rule+": "+finding.Message)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDiffDir(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "schema.json"), []byte("{\n  \"type\": \"object\"\n}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte("{}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "other.txt"), []byte("other\n"), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "add app")
+
+	os.WriteFile(filepath.Join(dir, "app", "schema.json"), []byte("{\n  \"type\": \"array\"\n}\n"), 0644)
+	os.Remove(filepath.Join(dir, "app", "data.json"))
+	os.WriteFile(filepath.Join(dir, "app", "uischema.json"), []byte("{}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "other.txt"), []byte("changed\n"), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "change app")
+
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	second, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := second.Parent(0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gitDir := filepath.Join(dir, git.GitDirName)
+	from := &checkoutCommit{Commit: first, gitDir: gitDir}
+	to := &checkoutCommit{Commit: second, gitDir: gitDir}
+	files, err := diffDir(from, to, "app")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := map[string]string{}
+
+	for _, file := range files {
+		actions[file.Path] = file.Action
+	}
+
+	want := map[string]string{"app/data.json": "deleted", "app/schema.json": "modified", "app/uischema.json": "added"}
+
+	if !reflect.DeepEqual(actions, want) {
+		t.Fatalf("got %v, want %v", actions, want)
+	}
+
+	for _, file := range files {
+		if file.Path == "app/schema.json" && (!strings.Contains(file.Patch, "-  \"type\": \"object\"") || !strings.Contains(file.Patch, "+  \"type\": \"array\"")) {
+			t.Errorf("got patch %s", file.Patch)
+		}
+	}
+
+	// A directory added since is diffed against an empty tree.
+	os.MkdirAll(filepath.Join(dir, "new"), 0755)
+	os.WriteFile(filepath.Join(dir, "new", "schema.json"), []byte("{}\n"), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "add new")
+	head, _ = r.Head()
+	third, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = diffDir(to, &checkoutCommit{Commit: third, gitDir: gitDir}, "new")
+
+	if err != nil || len(files) != 1 || files[0].Action != "added" || files[0].Path != "new/schema.json" {
+		t.Fatalf("got %v, %v", files, err)
+	}
+
+	if _, err := diffDir(from, to, "missing"); status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v for a missing directory", err)
+	}
+}
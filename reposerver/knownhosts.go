@@ -0,0 +1,321 @@
+package reposerver
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	KNOWN_HOSTS   = "known_hosts"
+	PENDING_HOSTS = "pending_known_hosts"
+
+	HOST_KEY_UNTRUSTED = "is not trusted yet, approve it to sync"
+	HOST_KEY_MISMATCH  = "HOST KEY MISMATCH"
+)
+
+var hostKeysLock sync.Mutex
+
+// UnknownHostKeyError is returned when a repo host presents a key which has
+// not been approved yet. The key is recorded as pending approval.
+type UnknownHostKeyError struct {
+	Host        string
+	Fingerprint string
+	err         error
+}
+
+func (e *UnknownHostKeyError) Error() string {
+	return fmt.Sprintf("host key %s for %s %s", e.Fingerprint, e.Host, HOST_KEY_UNTRUSTED)
+}
+
+func (e *UnknownHostKeyError) Unwrap() error {
+	return e.err
+}
+
+// HostKeyMismatchError is returned when a repo host presents a key which
+// differs from the trusted one, which may indicate a man in the middle.
+type HostKeyMismatchError struct {
+	Host        string
+	Fingerprint string
+	err         error
+}
+
+func (e *HostKeyMismatchError) Error() string {
+	return fmt.Sprintf("%s for %s: presented key %s does not match the trusted key", HOST_KEY_MISMATCH, e.Host, e.Fingerprint)
+}
+
+// Unwrap returns the error of the known hosts callback, from which go-git
+// reads the types of the trusted keys of a host.
+func (e *HostKeyMismatchError) Unwrap() error {
+	return e.err
+}
+
+type knownHost struct {
+	hosts []string
+	key   gossh.PublicKey
+	line  string
+}
+
+func knownHostsPath(name string) string {
+	return filepath.Join(os.Getenv(SSH_ROOT), name)
+}
+
+// initKnownHosts creates the known hosts store, which every ssh connection is
+// verified against by verifyHostKey.
+func initKnownHosts() error {
+	f, err := os.OpenFile(knownHostsPath(KNOWN_HOSTS), os.O_CREATE|os.O_RDONLY, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func readKnownHosts(name string) ([]knownHost, error) {
+	contents, err := os.ReadFile(knownHostsPath(name))
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []knownHost
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		_, hosts, key, _, _, err := gossh.ParseKnownHosts([]byte(line))
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entry in %s", name)
+		}
+
+		entries = append(entries, knownHost{hosts: hosts, key: key, line: line})
+	}
+
+	return entries, scanner.Err()
+}
+
+func writeKnownHosts(name string, entries []knownHost) error {
+	var buf bytes.Buffer
+
+	for _, entry := range entries {
+		buf.WriteString(entry.line + "\n")
+	}
+
+	return os.WriteFile(knownHostsPath(name), buf.Bytes(), 0600)
+}
+
+func (h knownHost) matches(host string, fingerprint string) bool {
+	if fingerprint != "" && gossh.FingerprintSHA256(h.key) != fingerprint {
+		return false
+	}
+
+	for _, pattern := range h.hosts {
+		if pattern == host || pattern == knownhosts.Normalize(host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func listHostKeys() ([]*HostKey, error) {
+	hostKeysLock.Lock()
+	defer hostKeysLock.Unlock()
+
+	var hostKeys []*HostKey
+
+	for _, name := range []string{KNOWN_HOSTS, PENDING_HOSTS} {
+		entries, err := readKnownHosts(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			hostKeys = append(hostKeys, &HostKey{
+				Host:        strings.Join(entry.hosts, ","),
+				KeyType:     entry.key.Type(),
+				Fingerprint: gossh.FingerprintSHA256(entry.key),
+				Pending:     name == PENDING_HOSTS,
+			})
+		}
+	}
+
+	return hostKeys, nil
+}
+
+// addHostKey trusts the key of a line in known_hosts format.
+func addHostKey(line string) error {
+	hostKeysLock.Lock()
+	defer hostKeysLock.Unlock()
+
+	return trustHostKey(line)
+}
+
+// approveHostKey moves a key recorded on first use into the trusted keys.
+func approveHostKey(host string, fingerprint string) error {
+	hostKeysLock.Lock()
+	defer hostKeysLock.Unlock()
+
+	pending, err := readKnownHosts(PENDING_HOSTS)
+
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range pending {
+		if entry.matches(host, fingerprint) {
+			return trustHostKey(entry.line)
+		}
+	}
+
+	return errors.Errorf("no pending host key %s for %s", fingerprint, host)
+}
+
+// trustHostKey adds a line to the trusted keys, removing it from the pending
+// ones. It is called holding hostKeysLock.
+func trustHostKey(line string) error {
+	_, hosts, key, _, _, err := gossh.ParseKnownHosts([]byte(line))
+
+	if err != nil {
+		return err
+	}
+
+	entries, err := readKnownHosts(KNOWN_HOSTS)
+
+	if err != nil {
+		return err
+	}
+
+	fingerprint := gossh.FingerprintSHA256(key)
+
+	for _, host := range hosts {
+		if err := removeHostKeyFrom(PENDING_HOSTS, host, fingerprint); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		if gossh.FingerprintSHA256(entry.key) == fingerprint && strings.Join(entry.hosts, ",") == strings.Join(hosts, ",") {
+			return nil
+		}
+	}
+
+	entries = append(entries, knownHost{hosts: hosts, key: key, line: strings.TrimSpace(line)})
+	return writeKnownHosts(KNOWN_HOSTS, entries)
+}
+
+func removeHostKey(host string, fingerprint string) error {
+	hostKeysLock.Lock()
+	defer hostKeysLock.Unlock()
+
+	err := removeHostKeyFrom(KNOWN_HOSTS, host, fingerprint)
+
+	if err != nil {
+		return err
+	}
+
+	return removeHostKeyFrom(PENDING_HOSTS, host, fingerprint)
+}
+
+func removeHostKeyFrom(name string, host string, fingerprint string) error {
+	entries, err := readKnownHosts(name)
+
+	if err != nil {
+		return err
+	}
+
+	kept := entries[:0]
+
+	for _, entry := range entries {
+		if !entry.matches(host, fingerprint) {
+			kept = append(kept, entry)
+		}
+	}
+
+	if len(kept) == len(entries) {
+		return nil
+	}
+
+	return writeKnownHosts(name, kept)
+}
+
+// recordPendingHostKey records an unknown key for approval. It is called
+// holding hostKeysLock.
+func recordPendingHostKey(host string, key gossh.PublicKey) error {
+	entries, err := readKnownHosts(PENDING_HOSTS)
+
+	if err != nil {
+		return err
+	}
+
+	line := knownhosts.Line([]string{host}, key)
+
+	for _, entry := range entries {
+		if entry.line == line {
+			return nil
+		}
+	}
+
+	entries = append(entries, knownHost{hosts: []string{knownhosts.Normalize(host)}, key: key, line: line})
+	return writeKnownHosts(PENDING_HOSTS, entries)
+}
+
+// verifyHostKey is the host key callback of ssh connections, checking the key
+// presented by a host against the trusted keys as go-git connects to it.
+// Unknown keys are recorded for approval.
+func verifyHostKey(host string, remote net.Addr, key gossh.PublicKey) error {
+	hostKeysLock.Lock()
+	defer hostKeysLock.Unlock()
+
+	callback, err := knownhosts.New(knownHostsPath(KNOWN_HOSTS))
+
+	if err != nil {
+		return err
+	}
+
+	err = callback(host, remote, key)
+	var keyErr *knownhosts.KeyError
+
+	if !errors.As(err, &keyErr) {
+		return err
+	}
+
+	fingerprint := gossh.FingerprintSHA256(key)
+
+	if len(keyErr.Want) > 0 {
+		return &HostKeyMismatchError{Host: host, Fingerprint: fingerprint, err: keyErr}
+	}
+
+	// go-git looks up the key types trusted for a host with a placeholder key,
+	// which is not recorded.
+	if _, parseErr := gossh.ParsePublicKey(key.Marshal()); parseErr == nil {
+		err = recordPendingHostKey(host, key)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return &UnknownHostKeyError{Host: host, Fingerprint: fingerprint, err: keyErr}
+}
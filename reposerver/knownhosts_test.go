@@ -0,0 +1,216 @@
+package reposerver
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/pkg/errors"
+	gossh "golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func hostKey(t *testing.T) gossh.Signer {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := gossh.NewSignerFromKey(private)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signer
+}
+
+func initTestKnownHosts(t *testing.T) {
+	t.Helper()
+	t.Setenv(SSH_ROOT, t.TempDir())
+
+	if err := initKnownHosts(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pendingHostKeys(t *testing.T) []*HostKey {
+	t.Helper()
+	hostKeys, err := listHostKeys()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pending []*HostKey
+
+	for _, hostKey := range hostKeys {
+		if hostKey.Pending {
+			pending = append(pending, hostKey)
+		}
+	}
+
+	return pending
+}
+
+func TestApproveHostKey(t *testing.T) {
+	initTestKnownHosts(t)
+	key := hostKey(t).PublicKey()
+	remote := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22}
+	var unknown *UnknownHostKeyError
+
+	if err := verifyHostKey("git.example.com:22", remote, key); !errors.As(err, &unknown) {
+		t.Fatalf("unknown host key verified, got %v", err)
+	}
+
+	pending := pendingHostKeys(t)
+
+	if len(pending) != 1 || pending[0].Fingerprint != gossh.FingerprintSHA256(key) {
+		t.Fatalf("got pending keys %v", pending)
+	}
+
+	if err := approveHostKey("git.example.com", pending[0].Fingerprint); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyHostKey("git.example.com:22", remote, key); err != nil {
+		t.Fatalf("approved host key not verified: %s", err)
+	}
+
+	if pending := pendingHostKeys(t); len(pending) != 0 {
+		t.Fatalf("approved key still pending: %v", pending)
+	}
+}
+
+func TestRejectHostKey(t *testing.T) {
+	initTestKnownHosts(t)
+	key := hostKey(t).PublicKey()
+	remote := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22}
+	verifyHostKey("git.example.com:22", remote, key)
+
+	if err := removeHostKey("git.example.com", gossh.FingerprintSHA256(key)); err != nil {
+		t.Fatal(err)
+	}
+
+	if pending := pendingHostKeys(t); len(pending) != 0 {
+		t.Fatalf("rejected key still pending: %v", pending)
+	}
+
+	if err := approveHostKey("git.example.com", gossh.FingerprintSHA256(key)); err == nil {
+		t.Fatal("approved a rejected key")
+	}
+}
+
+func TestChangedHostKey(t *testing.T) {
+	initTestKnownHosts(t)
+	trusted := hostKey(t).PublicKey()
+	remote := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22}
+	verifyHostKey("git.example.com:22", remote, trusted)
+
+	if err := approveHostKey("git.example.com", gossh.FingerprintSHA256(trusted)); err != nil {
+		t.Fatal(err)
+	}
+
+	var mismatch *HostKeyMismatchError
+
+	if err := verifyHostKey("git.example.com:22", remote, hostKey(t).PublicKey()); !errors.As(err, &mismatch) {
+		t.Fatalf("changed host key verified, got %v", err)
+	}
+
+	if pending := pendingHostKeys(t); len(pending) != 0 {
+		t.Fatalf("changed key recorded as pending: %v", pending)
+	}
+}
+
+// sshServer accepts ssh connections with a host key, without serving git.
+func sshServer(t *testing.T, signer gossh.Signer) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+	serverConfig := &gossh.ServerConfig{NoClientAuth: true}
+	serverConfig.AddHostKey(signer)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				_, channels, requests, err := gossh.NewServerConn(conn, serverConfig)
+
+				if err != nil {
+					return
+				}
+
+				go gossh.DiscardRequests(requests)
+
+				for channel := range channels {
+					channel.Reject(gossh.Prohibited, "no git here")
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestSshConnectionVerifiesHostKey(t *testing.T) {
+	initTestKnownHosts(t)
+	signer := hostKey(t)
+	address := sshServer(t, signer)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	auth, err := getPublicKey(privateKey, "git", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := func() error {
+		remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{"ssh://git@" + address + "/repo.git"}})
+		_, err := remote.List(&git.ListOptions{Auth: auth})
+		return hostKeyStatus(err)
+	}
+
+	if err := list(); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("connected to a host with an unknown key, got %v", err)
+	}
+
+	pending := pendingHostKeys(t)
+
+	if len(pending) != 1 || pending[0].Fingerprint != gossh.FingerprintSHA256(signer.PublicKey()) {
+		t.Fatalf("got pending keys %v", pending)
+	}
+
+	if err := approveHostKey(pending[0].Host, pending[0].Fingerprint); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := list(); err == nil || status.Code(err) == codes.FailedPrecondition {
+		t.Fatalf("host key not verified after approval, got %v", err)
+	}
+}
@@ -0,0 +1,305 @@
+package reposerver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	LFS_VERSION          = "https://git-lfs.github.com/spec/v1"
+	LFS_MEDIA_TYPE       = "application/vnd.git-lfs+json"
+	LFS_POINTER_MAX_SIZE = 1024
+	LFS_TIMEOUT          = time.Minute
+)
+
+var lfsOid = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// lfsPointer is the content of a Git LFS pointer file, which is committed in
+// place of the content of a file stored on the LFS server.
+type lfsPointer struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []struct {
+		lfsPointer
+		Actions map[string]struct {
+			Href   string            `json:"href"`
+			Header map[string]string `json:"header"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// parseLfsPointer recognizes pointer files, made of a version, an oid and a
+// size line.
+func parseLfsPointer(content []byte) (*lfsPointer, bool) {
+	if len(content) > LFS_POINTER_MAX_SIZE || !bytes.HasPrefix(content, []byte("version "+LFS_VERSION+"\n")) {
+		return nil, false
+	}
+
+	pointer := lfsPointer{Size: -1}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+
+		switch key {
+		case "oid":
+			pointer.Oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+
+			if err == nil {
+				pointer.Size = size
+			}
+		}
+	}
+
+	if !lfsOid.MatchString(pointer.Oid) || pointer.Size < 0 {
+		return nil, false
+	}
+
+	return &pointer, true
+}
+
+// lfsStore reads the LFS objects of a checkout from the object directory
+// git-lfs uses, downloading missing objects from the LFS server of the remote
+// of the checkout.
+type lfsStore struct {
+	enabled bool
+	dir     string
+	repoUrl string
+	auth    func(targetUrl string) (transport.AuthMethod, error)
+}
+
+// submodule reads the LFS objects of a submodule of the checkout, which has
+// its own object directory and LFS server.
+func (l *lfsStore) submodule(gitDir string, repoUrl string) *lfsStore {
+	if l == nil {
+		return nil
+	}
+
+	return &lfsStore{
+		enabled: l.enabled,
+		dir:     filepath.Join(gitDir, "lfs", "objects"),
+		repoUrl: repoUrl,
+		auth:    l.auth,
+	}
+}
+
+func (l *lfsStore) read(filePath string, pointer *lfsPointer) ([]byte, error) {
+	if l == nil || !l.enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is stored in Git LFS, which is not enabled for the repo", filePath)
+	}
+
+	if pointer.Size > MAX_FILE_SIZE {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is larger than %d bytes", filePath, MAX_FILE_SIZE)
+	}
+
+	objectPath := filepath.Join(l.dir, pointer.Oid[0:2], pointer.Oid[2:4], pointer.Oid)
+	content, err := os.ReadFile(objectPath)
+
+	if err == nil && verifyLfsObject(content, pointer) == nil {
+		return content, nil
+	}
+
+	content, err = l.download(pointer)
+
+	if err != nil && status.Code(err) == codes.Unknown {
+		err = status.Errorf(codes.Unavailable, "%s could not be downloaded from Git LFS: %s", filePath, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(objectPath), 0755)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects are written to a temporary file first, so concurrent readers
+	// never read half written objects.
+	tmp, err := os.CreateTemp(filepath.Dir(objectPath), pointer.Oid+".tmp*")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(content)
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return content, os.Rename(tmp.Name(), objectPath)
+}
+
+// download fetches an object with the batch API of the LFS server. The
+// credentials of the repo are only sent to the LFS server, not to the
+// storage it redirects to.
+func (l *lfsStore) download(pointer *lfsPointer) ([]byte, error) {
+	endpoint, err := lfsEndpoint(l.repoUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(lfsBatchRequest{Operation: "download", Transfers: []string{"basic"}, Objects: []lfsPointer{*pointer}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, endpoint+"/objects/batch", bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", LFS_MEDIA_TYPE)
+	request.Header.Set("Content-Type", LFS_MEDIA_TYPE)
+	auth, err := l.auth(endpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if basicAuth, ok := auth.(*githttp.BasicAuth); ok {
+		request.SetBasicAuth(basicAuth.Username, basicAuth.Password)
+	}
+
+	httpClient := &http.Client{Timeout: LFS_TIMEOUT}
+	response, err := httpClient.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("batch request returned %s", response.Status)
+	}
+
+	var batch lfsBatchResponse
+	err = json.NewDecoder(response.Body).Decode(&batch)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "batch request did not return JSON")
+	}
+
+	for _, object := range batch.Objects {
+		if object.Oid != pointer.Oid {
+			continue
+		}
+
+		if object.Error != nil {
+			return nil, errors.Errorf("object %s: %d %s", pointer.Oid, object.Error.Code, object.Error.Message)
+		}
+
+		action, ok := object.Actions["download"]
+
+		if !ok {
+			return nil, errors.Errorf("object %s has no download action", pointer.Oid)
+		}
+
+		log.Infof("downloading LFS object %s", pointer.Oid)
+		request, err := http.NewRequest(http.MethodGet, action.Href, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range action.Header {
+			request.Header.Set(key, value)
+		}
+
+		response, err := httpClient.Do(request)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("download of object %s returned %s", pointer.Oid, response.Status)
+		}
+
+		content, err := io.ReadAll(io.LimitReader(response.Body, pointer.Size+1))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return content, verifyLfsObject(content, pointer)
+	}
+
+	return nil, errors.Errorf("object %s is missing from the batch response", pointer.Oid)
+}
+
+func verifyLfsObject(content []byte, pointer *lfsPointer) error {
+	sum := sha256.Sum256(content)
+
+	if int64(len(content)) != pointer.Size || hex.EncodeToString(sum[:]) != pointer.Oid {
+		return errors.Errorf("object %s does not match its pointer", pointer.Oid)
+	}
+
+	return nil
+}
+
+// lfsEndpoint is the LFS server of a remote, at info/lfs of its http url.
+// Servers of ssh remotes need git-lfs-authenticate, which is not supported.
+func lfsEndpoint(repoUrl string) (string, error) {
+	endpoint, err := transport.NewEndpoint(repoUrl)
+
+	if err != nil {
+		return "", err
+	}
+
+	if endpoint.Protocol != "http" && endpoint.Protocol != "https" {
+		return "", status.Errorf(codes.Unimplemented, "Git LFS is only supported for repos cloned over http or https, not %s", endpoint.Protocol)
+	}
+
+	lfsUrl := strings.TrimSuffix(endpoint.String(), "/")
+
+	if !strings.HasSuffix(lfsUrl, ".git") {
+		lfsUrl += ".git"
+	}
+
+	return lfsUrl + "/info/lfs", nil
+}
@@ -0,0 +1,133 @@
+package reposerver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func lfsFixture(content string) (string, *lfsPointer) {
+	sum := sha256.Sum256([]byte(content))
+	oid := hex.EncodeToString(sum[:])
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", LFS_VERSION, oid, len(content)), &lfsPointer{Oid: oid, Size: int64(len(content))}
+}
+
+func TestParseLfsPointer(t *testing.T) {
+	text, want := lfsFixture("content")
+	pointer, ok := parseLfsPointer([]byte(text))
+
+	if !ok || *pointer != *want {
+		t.Fatalf("got %v, %v", pointer, ok)
+	}
+
+	for name, content := range map[string]string{
+		"plain file":   `{"type":"object"}`,
+		"invalid oid":  "version " + LFS_VERSION + "\noid sha256:1234\nsize 7\n",
+		"missing size": strings.Replace(text, "size 7\n", "", 1),
+		"too large":    text + strings.Repeat("x", LFS_POINTER_MAX_SIZE),
+	} {
+		if _, ok := parseLfsPointer([]byte(content)); ok {
+			t.Errorf("%s parsed as a pointer", name)
+		}
+	}
+}
+
+// lfsServer serves the batch API of the LFS server of repo.git, requiring the
+// basic auth user alice:s3cret, and the objects it links to.
+func lfsServer(t *testing.T, objects map[string]string) (string, *int) {
+	downloads := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repo.git/info/lfs/objects/batch" {
+			username, password, ok := r.BasicAuth()
+
+			if !ok || username != "alice" || password != "s3cret" {
+				http.Error(rw, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			var batch lfsBatchRequest
+			json.NewDecoder(r.Body).Decode(&batch)
+			oid := batch.Objects[0].Oid
+			fmt.Fprintf(rw, `{"objects":[{"oid":%q,"size":%d,"actions":{"download":{"href":"%s/objects/%s"}}}]}`, oid, batch.Objects[0].Size, server.URL, oid)
+			return
+		}
+
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Errorf("credentials sent to the storage of the LFS server")
+		}
+
+		downloads++
+		oid := strings.TrimPrefix(r.URL.Path, "/objects/")
+		content, ok := objects[oid]
+
+		if !ok {
+			http.NotFound(rw, r)
+			return
+		}
+
+		rw.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &downloads
+}
+
+func TestLfsStoreRead(t *testing.T) {
+	_, pointer := lfsFixture("content")
+	_, tampered := lfsFixture("tampered")
+	serverUrl, downloads := lfsServer(t, map[string]string{pointer.Oid: "content", tampered.Oid: "content"})
+	store := &lfsStore{
+		enabled: true,
+		dir:     t.TempDir(),
+		repoUrl: serverUrl + "/repo.git",
+		auth: func(string) (transport.AuthMethod, error) {
+			return &githttp.BasicAuth{Username: "alice", Password: "s3cret"}, nil
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		content, err := store.read("app/data.json", pointer)
+
+		if err != nil || string(content) != "content" {
+			t.Fatalf("read %d: %q, %v", i, content, err)
+		}
+	}
+
+	if *downloads != 1 {
+		t.Fatalf("got %d downloads, want the object to be cached after the first", *downloads)
+	}
+
+	if _, err := os.Stat(filepath.Join(store.dir, pointer.Oid[0:2], pointer.Oid[2:4], pointer.Oid)); err != nil {
+		t.Fatalf("object not stored: %s", err)
+	}
+
+	if _, err := store.read("app/tampered.json", tampered); status.Code(err) != codes.Unavailable {
+		t.Fatalf("read an object not matching its pointer, got %v", err)
+	}
+
+	store.auth = func(string) (transport.AuthMethod, error) {
+		return nil, nil
+	}
+
+	if _, err := store.download(tampered); err == nil {
+		t.Fatal("downloaded without credentials")
+	}
+
+	store.enabled = false
+
+	if _, err := store.read("app/data.json", pointer); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("read with LFS disabled, got %v", err)
+	}
+}
@@ -0,0 +1,457 @@
+package reposerver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	LEVEL_ERROR   = "error"
+	LEVEL_WARNING = "warning"
+
+	RULE_MANIFEST = "manifest"
+	RULE_SCHEMA   = "schema"
+	RULE_DATA     = "data"
+	RULE_SCOPE    = "uischema-scope"
+	RULE_TEMPLATE = "template"
+	RULE_POLICY   = "policy"
+
+	schemaURL = "mem://schema.json"
+)
+
+// Rules describes the checks run on the manifests of an application.
+var Rules = map[string]string{
+	RULE_MANIFEST: "Manifests are JSON, YAML or JSON5 objects whose $refs resolve",
+	RULE_SCHEMA:   "The schema is a valid JSON Schema",
+	RULE_DATA:     "The sample data validates against the schema",
+	RULE_SCOPE:    "The scopes of the uischema exist in the schema",
+	RULE_TEMPLATE: "The sample data renders the job or resource of the application",
+	RULE_POLICY:   "The rendered job or resource passes the policy rules of the server",
+}
+
+var pathToken = regexp.MustCompile(`[^.\[\]]+`)
+
+// LintDir checks the manifests of dir, a directory of the working tree at
+// root. Resource is set for applications with a status check, which create a
+// resource rather than a job.
+func LintDir(root string, dir string, resource bool) ([]*Finding, error) {
+	dir, err := RepoPath(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names, read, err := dirReader(root, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lint(dir, names, read, resource), nil
+}
+
+func (s RepoService) ValidateApplication(_ context.Context, request *ValidateRequest) (*ValidateResponse, error) {
+	commit, release, err := s.commitAt(request.Repo, request.RepoId, request.Ref, request.Options, request.Hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	dir, err := RepoPath(request.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names, read, err := commitReader(commit, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidateResponse{
+		Hash:     commit.Hash.String(),
+		Findings: lint(dir, names, read, request.Resource),
+	}, nil
+}
+
+// lint checks the manifests of a directory the way they are used: the form is
+// rendered from the schema and uischema, its sample data is submitted by the
+// UI and turned into a job or resource by the server.
+func lint(dir string, names map[string]bool, read readFunc, resource bool) []*Finding {
+	var manifests ManifestsResponse
+	err := loadManifests(&manifests, dir, names, read)
+
+	if err != nil {
+		return []*Finding{manifestFinding(dir, err)}
+	}
+
+	files := map[string]string{}
+
+	for _, kind := range manifestKinds {
+		if name := manifestFile(names, kind); name != "" {
+			files[kind] = path.Join(dir, name)
+		}
+	}
+
+	if manifests.Schema == nil {
+		return []*Finding{{Rule: RULE_MANIFEST, Level: LEVEL_ERROR, File: dir, Message: "no schema manifest"}}
+	}
+
+	schema := manifests.Schema.AsMap()
+	compiled, findings := compileSchema(files["schema"], schema)
+	findings = append(findings, lintOptions(files["schema"], schema)...)
+
+	if manifests.Data == nil {
+		findings = append(findings, &Finding{Rule: RULE_DATA, Level: LEVEL_WARNING, File: dir, Message: "no data manifest, the form starts empty"})
+	} else {
+		data := manifests.Data.AsMap()
+
+		if compiled != nil {
+			findings = append(findings, validateData(files["data"], compiled, data)...)
+		}
+
+		findings = append(findings, lintTemplate(files["data"], data, resource)...)
+	}
+
+	if manifests.UiSchema != nil {
+		findings = append(findings, lintScopes(files["uischema"], schema, manifests.UiSchema.AsMap())...)
+	}
+
+	return findings
+}
+
+// manifestFinding reports manifests of dir which could not be loaded.
+func manifestFinding(dir string, err error) *Finding {
+	var manifestErr *ManifestError
+
+	if errors.As(err, &manifestErr) {
+		return &Finding{Rule: RULE_MANIFEST, Level: LEVEL_ERROR, File: manifestErr.File, Line: int32(manifestErr.Line), Message: manifestErr.Message}
+	}
+
+	return &Finding{Rule: RULE_MANIFEST, Level: LEVEL_ERROR, File: dir, Message: status.Convert(err).Message()}
+}
+
+func compileSchema(file string, schema map[string]interface{}) (*jsonschema.Schema, []*Finding) {
+	content, err := json.Marshal(schema)
+
+	if err != nil {
+		return nil, []*Finding{{Rule: RULE_SCHEMA, Level: LEVEL_ERROR, File: file, Message: err.Error()}}
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, errors.Errorf("remote $ref %s is not loaded", url)
+	}
+	err = compiler.AddResource(schemaURL, bytes.NewReader(content))
+
+	if err == nil {
+		var compiled *jsonschema.Schema
+		compiled, err = compiler.Compile(schemaURL)
+
+		if err == nil {
+			return compiled, nil
+		}
+	}
+
+	var schemaErr *jsonschema.SchemaError
+
+	if errors.As(err, &schemaErr) {
+		err = schemaErr.Err
+	}
+
+	var validationErr *jsonschema.ValidationError
+
+	if !errors.As(err, &validationErr) {
+		return nil, []*Finding{{Rule: RULE_SCHEMA, Level: LEVEL_ERROR, File: file, Message: strings.TrimPrefix(err.Error(), "jsonschema: ")}}
+	}
+
+	var findings []*Finding
+
+	for _, cause := range leafErrors(validationErr) {
+		findings = append(findings, &Finding{Rule: RULE_SCHEMA, Level: LEVEL_ERROR, File: file, Message: location(cause.InstanceLocation) + ": " + cause.Message})
+	}
+
+	return nil, findings
+}
+
+func validateData(file string, compiled *jsonschema.Schema, data map[string]interface{}) []*Finding {
+	err := compiled.Validate(data)
+
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+
+	if !errors.As(err, &validationErr) {
+		return []*Finding{{Rule: RULE_DATA, Level: LEVEL_ERROR, File: file, Message: err.Error()}}
+	}
+
+	var findings []*Finding
+
+	for _, cause := range leafErrors(validationErr) {
+		findings = append(findings, &Finding{Rule: RULE_DATA, Level: LEVEL_ERROR, File: file, Message: location(cause.InstanceLocation) + ": " + cause.Message})
+	}
+
+	return findings
+}
+
+// leafErrors flattens a validation error into its causes.
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	var leaves []*jsonschema.ValidationError
+
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+
+	sort.SliceStable(leaves, func(i, j int) bool {
+		return leaves[i].InstanceLocation < leaves[j].InstanceLocation
+	})
+
+	return leaves
+}
+
+func location(instanceLocation string) string {
+	if instanceLocation == "" {
+		return "/"
+	}
+
+	if unescaped, err := url.PathUnescape(instanceLocation); err == nil {
+		return unescaped
+	}
+
+	return instanceLocation
+}
+
+// lintOptions checks the option sources of the fields of a schema, and that
+// the fields they depend on exist.
+func lintOptions(file string, schema map[string]interface{}) []*Finding {
+	var findings []*Finding
+	properties, _ := schema["properties"].(map[string]interface{})
+	fields := make([]string, 0, len(properties))
+
+	for field := range properties {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fieldSchema, _ := properties[field].(map[string]interface{})
+		extension, ok := fieldSchema[client.OPTIONS_EXTENSION]
+
+		if !ok {
+			continue
+		}
+
+		source, err := client.ParseOptionSource(extension)
+
+		if err != nil {
+			findings = append(findings, &Finding{Rule: RULE_SCHEMA, Level: LEVEL_ERROR, File: file, Message: field + ": " + err.Error()})
+			continue
+		}
+
+		for _, dependency := range source.Dependencies() {
+			if _, ok := properties[dependency]; !ok {
+				findings = append(findings, &Finding{Rule: RULE_SCHEMA, Level: LEVEL_ERROR, File: file, Message: field + ": options depend on field " + dependency + ", which is not in the schema"})
+			}
+		}
+	}
+
+	return findings
+}
+
+// lintScopes checks the scopes of the controls and rules of a uischema point
+// into the schema.
+func lintScopes(file string, schema map[string]interface{}, uischema map[string]interface{}) []*Finding {
+	var findings []*Finding
+	var walk func(node interface{})
+
+	walk = func(node interface{}) {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			if scope, ok := v["scope"].(string); ok {
+				if !strings.HasPrefix(scope, "#") {
+					findings = append(findings, &Finding{Rule: RULE_SCOPE, Level: LEVEL_ERROR, File: file, Message: "scope " + scope + " is not a local JSON pointer"})
+				} else if _, err := lookupPointer(schema, strings.TrimPrefix(scope, "#")); err != nil {
+					findings = append(findings, &Finding{Rule: RULE_SCOPE, Level: LEVEL_ERROR, File: file, Message: "scope " + scope + " does not exist in the schema"})
+				}
+			}
+
+			keys := make([]string, 0, len(v))
+
+			for key := range v {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+
+	walk(uischema)
+	return findings
+}
+
+// lintTemplate renders the object created from the sample data, the keys of
+// which are paths into the object as set by the UI, then checks the server
+// accepts it.
+func lintTemplate(file string, data map[string]interface{}, resource bool) []*Finding {
+	var findings []*Finding
+	keys := make([]string, 0, len(data))
+
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	var object interface{} = map[string]interface{}{}
+
+	for _, key := range keys {
+		rendered, err := setPath(object, parsePath(key), data[key])
+
+		if err != nil {
+			findings = append(findings, &Finding{Rule: RULE_TEMPLATE, Level: LEVEL_ERROR, File: file, Message: key + ": " + err.Error()})
+			continue
+		}
+
+		object = rendered
+	}
+
+	content, err := json.Marshal(object)
+
+	if err != nil {
+		return append(findings, &Finding{Rule: RULE_TEMPLATE, Level: LEVEL_ERROR, File: file, Message: err.Error()})
+	}
+
+	var violations []string
+
+	if resource {
+		var resourceConfig client.ResourceConfig
+		findings = append(findings, decodeTemplate(file, content, &resourceConfig)...)
+		violations = client.ResourceViolations(resourceConfig)
+	} else {
+		var jobConfig client.JobConfig
+		findings = append(findings, decodeTemplate(file, content, &jobConfig)...)
+		violations = client.JobViolations(jobConfig)
+	}
+
+	for _, violation := range violations {
+		findings = append(findings, &Finding{Rule: RULE_POLICY, Level: LEVEL_ERROR, File: file, Message: violation})
+	}
+
+	return findings
+}
+
+// decodeTemplate decodes a rendered object as the server does, and again
+// strictly to warn about fields the server ignores.
+func decodeTemplate(file string, content []byte, config interface{}) []*Finding {
+	err := json.Unmarshal(content, config)
+
+	if err != nil {
+		return []*Finding{{Rule: RULE_TEMPLATE, Level: LEVEL_ERROR, File: file, Message: "rendered object: " + err.Error()}}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(config)
+
+	if err != nil {
+		return []*Finding{{Rule: RULE_TEMPLATE, Level: LEVEL_WARNING, File: file, Message: "rendered object: " + strings.TrimPrefix(err.Error(), "json: ") + ", ignored by the server"}}
+	}
+
+	return nil
+}
+
+// parsePath splits a path such as spec.containers[0].image into its keys and
+// indexes.
+func parsePath(key string) []interface{} {
+	var tokens []interface{}
+
+	for _, token := range pathToken.FindAllString(key, -1) {
+		if i, err := strconv.Atoi(token); err == nil && i >= 0 {
+			tokens = append(tokens, i)
+		} else {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
+
+// setPath sets a value at a path, creating the objects and arrays along it.
+func setPath(node interface{}, tokens []interface{}, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	switch token := tokens[0].(type) {
+	case int:
+		array, ok := node.([]interface{})
+
+		if !ok && node != nil {
+			return nil, errors.Errorf("index %d of a value which is not an array", token)
+		}
+
+		for len(array) <= token {
+			array = append(array, nil)
+		}
+
+		child, err := setPath(array[token], tokens[1:], value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		array[token] = child
+		return array, nil
+	default:
+		key := token.(string)
+		object, ok := node.(map[string]interface{})
+
+		if !ok && node != nil {
+			return nil, errors.Errorf("key %s of a value which is not an object", key)
+		}
+
+		if object == nil {
+			object = map[string]interface{}{}
+		}
+
+		child, err := setPath(object[key], tokens[1:], value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		object[key] = child
+		return object, nil
+	}
+}
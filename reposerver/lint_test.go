@@ -0,0 +1,149 @@
+package reposerver
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func writeApplication(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+
+	for name, content := range files {
+		filePath := filepath.Join(root, "app", name)
+		os.MkdirAll(filepath.Dir(filePath), 0755)
+
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+const lintJobData = `{
+	"metadata.name": "backup",
+	"spec.template.spec.containers[0].name": "main",
+	"spec.template.spec.containers[0].image": "busybox",
+	"spec.template.spec.restartPolicy": "Never"
+}`
+
+func TestLintDirValid(t *testing.T) {
+	root := writeApplication(t, map[string]string{
+		"schema.json":   `{"type": "object", "properties": {"metadata.name": {"type": "string"}}}`,
+		"data.json":     lintJobData,
+		"uischema.json": `{"type": "Control", "scope": "#/properties/metadata.name"}`,
+	})
+	findings, err := LintDir(root, "app", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 0 {
+		t.Fatalf("got findings %v", findings)
+	}
+}
+
+func TestLintDirFindings(t *testing.T) {
+	root := writeApplication(t, map[string]string{
+		"schema.json":   `{"type": "object", "required": ["size"], "properties": {"size": {"type": "integer"}}}`,
+		"data.json":     `{"size": "large", "spec.template.spec.restartPolicy": "Always"}`,
+		"uischema.json": `{"type": "Control", "scope": "#/properties/missing"}`,
+	})
+	findings, err := LintDir(root, "/app", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := map[string]bool{}
+
+	for _, finding := range findings {
+		rules[finding.Rule] = true
+
+		if finding.Level != LEVEL_ERROR && finding.Rule != RULE_TEMPLATE {
+			t.Errorf("finding %v is not an error", finding)
+		}
+	}
+
+	for _, rule := range []string{RULE_DATA, RULE_SCOPE, RULE_POLICY} {
+		if !rules[rule] {
+			t.Errorf("no %s finding in %v", rule, findings)
+		}
+	}
+}
+
+func TestLintDirManifestError(t *testing.T) {
+	root := writeApplication(t, map[string]string{
+		"schema.yaml": "type: object\nproperties:\n  size: [\n",
+	})
+	findings, err := LintDir(root, "app", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 1 || findings[0].Rule != RULE_MANIFEST || findings[0].File != "app/schema.yaml" || findings[0].Line == 0 {
+		t.Fatalf("got findings %v", findings)
+	}
+
+	if _, err := LintDir(root, "../app", false); err == nil {
+		t.Fatal("linted a directory outside of the root")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := map[string][]interface{}{
+		"metadata.name":                          {"metadata", "name"},
+		"spec.template.spec.containers[0].image": {"spec", "template", "spec", "containers", 0, "image"},
+		"args[1]":                                {"args", 1},
+		"matrix[0][2]":                           {"matrix", 0, 2},
+		"labels.app":                             {"labels", "app"},
+		"spec.containers[-1]":                    {"spec", "containers", "-1"},
+	}
+
+	for key, want := range tests {
+		if got := parsePath(key); !reflect.DeepEqual(got, want) {
+			t.Errorf("parsePath(%s) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	var object interface{} = map[string]interface{}{}
+	keys := []string{"spec.containers[1].image", "spec.containers[0].name", "metadata.name"}
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		var err error
+		object, err = setPath(object, parsePath(key), i)
+
+		if err != nil {
+			t.Fatalf("%s: %s", key, err)
+		}
+	}
+
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": 0},
+		"spec": map[string]interface{}{"containers": []interface{}{
+			map[string]interface{}{"name": 1},
+			map[string]interface{}{"image": 2},
+		}},
+	}
+
+	if !reflect.DeepEqual(object, want) {
+		t.Fatalf("got %v, want %v", object, want)
+	}
+
+	if _, err := setPath(object, parsePath("metadata.name.first"), 1); err == nil {
+		t.Error("set a key of a value which is not an object")
+	}
+
+	if _, err := setPath(object, parsePath("spec[0]"), 1); err == nil {
+		t.Error("set an index of a value which is not an array")
+	}
+}
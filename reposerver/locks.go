@@ -0,0 +1,134 @@
+package reposerver
+
+import (
+	"sync"
+)
+
+// checkoutLocks guards the checkouts of repos. Clones, pulls, fetches and
+// removals of a checkout hold its write lock, so two of them never touch the
+// same worktree and readers wait until the checkout is consistent again.
+// Concurrent writes with the same key are coalesced: a write made while one
+// runs waits for a single run after it, shared with the other writes made in
+// the meantime, so no caller gets the result of a run started before it.
+// Entries are removed once no one holds or waits for them.
+type checkoutLocks struct {
+	mu      sync.Mutex
+	locks   map[string]*checkoutLock
+	flights map[string]*flights
+}
+
+type checkoutLock struct {
+	sync.RWMutex
+	refs int
+}
+
+// flights are the running write of a key, and the one queued after it.
+type flights struct {
+	running *flight
+	next    *flight
+}
+
+type flight struct {
+	done chan struct{}
+	err  error
+}
+
+func newCheckoutLocks() *checkoutLocks {
+	return &checkoutLocks{locks: map[string]*checkoutLock{}, flights: map[string]*flights{}}
+}
+
+func (l *checkoutLocks) acquire(dir string) *checkoutLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[dir]
+
+	if !ok {
+		lock = &checkoutLock{}
+		l.locks[dir] = lock
+	}
+
+	lock.refs++
+	return lock
+}
+
+func (l *checkoutLocks) release(dir string, lock *checkoutLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.refs--
+
+	if lock.refs == 0 {
+		delete(l.locks, dir)
+	}
+}
+
+// read holds the read lock of a checkout until the returned func is called.
+func (l *checkoutLocks) read(dir string) func() {
+	lock := l.acquire(dir)
+	lock.RLock()
+
+	return func() {
+		lock.RUnlock()
+		l.release(dir, lock)
+	}
+}
+
+// exclusive holds the write lock of a checkout until the returned func is
+// called, without coalescing with other writes.
+func (l *checkoutLocks) exclusive(dir string) func() {
+	lock := l.acquire(dir)
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+		l.release(dir, lock)
+	}
+}
+
+// write runs fn holding the write lock of a checkout. Calls made with the
+// same key while fn runs wait for the next run rather than running again.
+func (l *checkoutLocks) write(dir string, key string, fn func() error) error {
+	l.mu.Lock()
+	state, ok := l.flights[key]
+
+	if !ok {
+		state = &flights{}
+		l.flights[key] = state
+	}
+
+	if state.next != nil {
+		f := state.next
+		l.mu.Unlock()
+		<-f.done
+		return f.err
+	}
+
+	f := &flight{done: make(chan struct{})}
+
+	if running := state.running; running != nil {
+		state.next = f
+		l.mu.Unlock()
+		<-running.done
+		l.mu.Lock()
+		state.next = nil
+	}
+
+	state.running = f
+	l.mu.Unlock()
+
+	unlock := l.exclusive(dir)
+	f.err = fn()
+	unlock()
+
+	l.mu.Lock()
+	state.running = nil
+
+	if state.next == nil {
+		delete(l.flights, key)
+	}
+
+	l.mu.Unlock()
+	close(f.done)
+	return f.err
+}
@@ -0,0 +1,125 @@
+package reposerver
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckoutLocksHammer(t *testing.T) {
+	locks := newCheckoutLocks()
+	dirs := []string{"1", "1@main", "2"}
+	readers := make([]int32, len(dirs))
+	writers := make([]int32, len(dirs))
+	// requested counts the writes made to each dir, applied is the count a run
+	// saw when it started.
+	requested := make([]int64, len(dirs))
+	applied := make([]int64, len(dirs))
+	var wg sync.WaitGroup
+
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 200; j++ {
+				d := (i + j) % len(dirs)
+				dir := dirs[d]
+
+				if j%3 == 0 {
+					release := locks.read(dir)
+
+					atomic.AddInt32(&readers[d], 1)
+
+					if atomic.LoadInt32(&writers[d]) != 0 {
+						t.Errorf("%s read while written", dir)
+					}
+
+					time.Sleep(time.Microsecond)
+					atomic.AddInt32(&readers[d], -1)
+					release()
+					continue
+				}
+
+				request := atomic.AddInt64(&requested[d], 1)
+				err := locks.write(dir, "sync "+dir, func() error {
+					atomic.StoreInt64(&applied[d], atomic.LoadInt64(&requested[d]))
+
+					if atomic.AddInt32(&writers[d], 1) != 1 || atomic.LoadInt32(&readers[d]) != 0 {
+						t.Errorf("%s written concurrently", dir)
+					}
+
+					time.Sleep(10 * time.Microsecond)
+					atomic.AddInt32(&writers[d], -1)
+					return fmt.Errorf("run %d", atomic.LoadInt64(&applied[d]))
+				})
+
+				var seen int64
+				fmt.Sscanf(err.Error(), "run %d", &seen)
+
+				if seen < request {
+					t.Errorf("%s: write %d got the result of a run started before it (%d)", dir, request, seen)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if len(locks.locks) != 0 || len(locks.flights) != 0 {
+		t.Fatalf("%d locks and %d flights left", len(locks.locks), len(locks.flights))
+	}
+}
+
+func TestCheckoutLocksRerun(t *testing.T) {
+	locks := newCheckoutLocks()
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var runs int32
+
+	go locks.write("1", "sync", func() error {
+		atomic.AddInt32(&runs, 1)
+		close(started)
+		<-unblock
+		return nil
+	})
+
+	<-started
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			locks.write("1", "sync", func() error {
+				atomic.AddInt32(&runs, 1)
+				return nil
+			})
+		}()
+	}
+
+	// Wait for the writes to queue behind the running one.
+	for {
+		locks.mu.Lock()
+		queued := locks.flights["sync"].next != nil
+		locks.mu.Unlock()
+
+		if queued {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(unblock)
+	wg.Wait()
+
+	if runs != 2 {
+		t.Fatalf("got %d runs, want the running one and one more", runs)
+	}
+}
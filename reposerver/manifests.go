@@ -0,0 +1,402 @@
+package reposerver
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	giturl "github.com/kubescape/go-git-url"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openCheckout opens the checkout of the requested ref, syncing refs which
+// were not checked out yet, or whose repo url or options changed, with the
+// options of the repo as its next sync would. Requests without options use
+// the options the checkout was synced with. The checkout is read locked until
+// release is called.
+func (s RepoService) openCheckout(repoUrl string, repoId string, ref string, repoOptions *RepoOptions) (*git.Repository, string, func(), error) {
+	_, err := giturl.NewGitURL(repoUrl)
+
+	if err != nil {
+		return nil, "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if !repoIdPattern.MatchString(repoId) {
+		return nil, "", nil, status.Error(codes.InvalidArgument, "repoId is required")
+	}
+
+	repoDir := checkoutDir(s.repoRoot, repoId, ref)
+	release := s.locks.read(repoDir)
+	r, err := git.PlainOpen(repoDir)
+	options := syncedOptions(r, repoOptions)
+
+	if err == nil && (originUrl(r) != repoUrl || optionsChanged(r, options) || settingsChanged(r, options)) {
+		err = git.ErrRepositoryNotExists
+	}
+
+	if err == git.ErrRepositoryNotExists {
+		release()
+		err = options.trust()
+
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		err = s.syncCheckout(repoUrl, repoId, repoDir, ref, options)
+
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		release = s.locks.read(repoDir)
+		r, err = git.PlainOpen(repoDir)
+	}
+
+	if err != nil {
+		release()
+		return nil, "", nil, err
+	}
+
+	return r, repoDir, release, nil
+}
+
+// syncCheckout clones, pulls or fetches a checkout. Concurrent syncs of a
+// checkout are coalesced into one, whose progress goes to the listeners of
+// syncKey.
+func (s RepoService) syncCheckout(repoUrl string, repoId string, repoDir string, ref string, options cloneOptions) error {
+	key := syncKey(repoUrl, repoDir)
+
+	err := s.locks.write(repoDir, key, func() error {
+		_, err := doSync(s.secrets, repoId, repoUrl, repoDir, ref, options, s.progress.progress(key))
+		return err
+	})
+
+	return hostKeyStatus(err)
+}
+
+func syncKey(repoUrl string, repoDir string) string {
+	return "sync " + repoDir + " " + repoUrl
+}
+
+// commitAt resolves the requested commit of a ref, or its checked out commit.
+// Commits missing from the checkout are fetched once. The checkout is read
+// locked until release is called, as objects are read from it lazily.
+func (s RepoService) commitAt(repoUrl string, repoId string, ref string, options *RepoOptions, hash string) (*checkoutCommit, func(), error) {
+	commits, release, err := s.commitsAt(repoUrl, repoId, ref, options, hash)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commits[0], release, nil
+}
+
+// commitsAt resolves several commits of a ref under the same read lock, see
+// commitAt.
+func (s RepoService) commitsAt(repoUrl string, repoId string, ref string, options *RepoOptions, hashes ...string) ([]*checkoutCommit, func(), error) {
+	r, repoDir, release, err := s.openCheckout(repoUrl, repoId, ref, options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commits, err := resolveCommits(r, hashes)
+
+	if status.Code(err) == codes.NotFound {
+		// Fetched as the checkout was cloned, single branch checkouts
+		// fetching only their branch.
+		recorded, _ := recordedOptions(r)
+		release()
+		err = s.locks.write(repoDir, "fetch "+repoDir+" "+repoUrl, func() error {
+			_, err := fetchRepo(s.secrets, repoId, repoUrl, repoDir, recorded, nil)
+			return err
+		})
+
+		if err != nil {
+			return nil, nil, hostKeyStatus(err)
+		}
+
+		release = s.locks.read(repoDir)
+		r, err = git.PlainOpen(repoDir)
+
+		if err == nil {
+			commits, err = resolveCommits(r, hashes)
+		}
+
+		// Fetching with the depth does not deepen shallow checkouts, and
+		// go-git cannot unshallow them.
+		if status.Code(err) == codes.NotFound && recorded.depth > 0 {
+			err = status.Errorf(codes.FailedPrecondition, "%s in the last %d commits of the shallow checkout, sync the repo with a larger depth to read older commits", status.Convert(err).Message(), recorded.depth)
+		}
+	}
+
+	if err == nil {
+		err = verifyCommits(commits, options)
+	}
+
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+
+	recorded, _ := recordedOptions(r)
+	gitDir := filepath.Join(repoDir, git.GitDirName)
+	lfs := &lfsStore{
+		enabled: recorded.lfs,
+		dir:     filepath.Join(gitDir, "lfs", "objects"),
+		repoUrl: repoUrl,
+		auth: func(targetUrl string) (transport.AuthMethod, error) {
+			return reuseAuth(s.secrets, repoId, repoUrl, targetUrl)
+		},
+	}
+	checkoutCommits := make([]*checkoutCommit, len(commits))
+
+	for i, commit := range commits {
+		checkoutCommits[i] = &checkoutCommit{Commit: commit, gitDir: gitDir, lfs: lfs}
+	}
+
+	return checkoutCommits, release, nil
+}
+
+// verifyCommits refuses to read commits of repos requiring signed commits
+// which are not signed by a trusted key, whether checked out or requested by
+// hash.
+func verifyCommits(commits []*object.Commit, options *RepoOptions) error {
+	verified := repoOptions(options)
+
+	if !verified.signed {
+		return nil
+	}
+
+	err := verified.trust()
+
+	if err != nil {
+		return err
+	}
+
+	for _, commit := range commits {
+		_, err = verifyCommit(commit, verified.trustedKeys)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchRemoteRef fetches a branch or tag listed by the remote into the
+// checkout of ref rather than cloning it, and returns the commit it names.
+func (s RepoService) fetchRemoteRef(repoUrl string, repoId string, ref string, options *RepoOptions, remoteRef string) (string, error) {
+	r, repoDir, release, err := s.openCheckout(repoUrl, repoId, ref, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	recorded, _ := recordedOptions(r)
+	release()
+	auth, err := getAuth(s.secrets, repoId, repoUrl)
+
+	if err != nil {
+		return "", err
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{repoUrl}})
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+
+	if err != nil {
+		return "", hostKeyStatus(err)
+	}
+
+	name := listedReference(refs, remoteRef)
+
+	if name == "" {
+		return "", status.Errorf(codes.NotFound, "%s is not a branch or tag of the repo", remoteRef)
+	}
+
+	local := name
+
+	if name.IsBranch() {
+		local = plumbing.NewRemoteReferenceName("origin", name.Short())
+	}
+
+	err = s.locks.write(repoDir, "fetch "+repoDir+" "+name.String(), func() error {
+		r, err := git.PlainOpen(repoDir)
+
+		if err != nil {
+			return err
+		}
+
+		err = r.Fetch(&git.FetchOptions{
+			RemoteName: "origin",
+			RefSpecs:   []config.RefSpec{config.RefSpec("+" + name + ":" + local)},
+			Depth:      recorded.depth,
+			Auth:       auth,
+			Force:      true,
+		})
+
+		if err == git.NoErrAlreadyUpToDate {
+			return nil
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return "", hostKeyStatus(err)
+	}
+
+	release = s.locks.read(repoDir)
+	defer release()
+	r, err = git.PlainOpen(repoDir)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := r.ResolveRevision(plumbing.Revision(local))
+
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
+func resolveCommits(r *git.Repository, hashes []string) ([]*object.Commit, error) {
+	commits := make([]*object.Commit, len(hashes))
+
+	for i, hash := range hashes {
+		commit, err := resolveCommit(r, hash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		commits[i] = commit
+	}
+
+	return commits, nil
+}
+
+func resolveCommit(r *git.Repository, hash string) (*object.Commit, error) {
+	if hash == "" {
+		head, err := r.Head()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return r.CommitObject(head.Hash())
+	}
+
+	resolved, err := r.ResolveRevision(plumbing.Revision(hash))
+
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "commit %s not found", hash)
+	}
+
+	return r.CommitObject(*resolved)
+}
+
+// getCommitManifests reads the manifests from the git objects of a commit
+// rather than the working tree, so a concurrent sync cannot return half
+// updated files and the manifests of past jobs can still be read.
+func (s RepoService) getCommitManifests(manifestsRequest *ManifestsRequest) (*ManifestsResponse, error) {
+	commit, release, err := s.commitAt(manifestsRequest.Repo, manifestsRequest.RepoId, manifestsRequest.Ref, manifestsRequest.Options, manifestsRequest.Hash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	dir, err := RepoPath(manifestsRequest.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names, read, err := commitReader(commit, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifestResp := ManifestsResponse{
+		Hash: commit.Hash.String(),
+		Ref:  manifestsRequest.Ref,
+	}
+	err = loadManifests(&manifestResp, dir, names, read)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifestResp.Templates, err = commitTemplates(commit, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifestResp, nil
+}
+
+// commitTemplates reads the files under the templates directory of an
+// application, keyed by their path relative to it.
+func commitTemplates(commit *checkoutCommit, dir string) (map[string]string, error) {
+	templatesDir := path.Join(dir, TEMPLATES_DIR)
+	tree, err := optionalDirTree(commit, templatesDir)
+
+	if tree == nil || err != nil {
+		return nil, err
+	}
+
+	templates := map[string]string{}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		content, _, err := readBlob(commit, path.Join(templatesDir, file.Name))
+
+		if err != nil {
+			return err
+		}
+
+		templates[file.Name] = string(content)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// commitReader lists the files of a directory of a commit, and reads files
+// of the commit.
+func commitReader(commit *checkoutCommit, dir string) (map[string]bool, readFunc, error) {
+	tree, err := commit.dirTree(dir)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := map[string]bool{}
+
+	for _, entry := range tree.Entries {
+		if entry.Mode.IsFile() {
+			names[entry.Name] = true
+		}
+	}
+
+	return names, func(filePath string) ([]byte, error) {
+		content, _, err := readBlob(commit, filePath)
+		return content, err
+	}, nil
+}
@@ -0,0 +1,90 @@
+package reposerver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCommitTemplates(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	os.MkdirAll(filepath.Join(dir, "app", "templates", "nested"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "app", "templates", "job.yaml"), []byte("kind: Job\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "app", "templates", "nested", "notes.txt"), []byte("notes"), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &checkoutCommit{Commit: commit, gitDir: filepath.Join(dir, git.GitDirName), lfs: &lfsStore{}}
+	templates, err := commitTemplates(c, "app")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(templates) != 2 || templates["job.yaml"] != "kind: Job\n" || templates["nested/notes.txt"] != "notes" {
+		t.Fatalf("got templates %v", templates)
+	}
+
+	templates, err = commitTemplates(c, "missing")
+
+	if err != nil || templates != nil {
+		t.Fatalf("got templates %v, err %v", templates, err)
+	}
+}
+
+func TestVerifyCommits(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "unsigned")
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits := []*object.Commit{commit}
+	t.Setenv(SSH_ROOT, t.TempDir())
+
+	if err := verifyCommits(commits, nil); err != nil {
+		t.Fatalf("verified a repo not requiring signed commits: %s", err)
+	}
+
+	signed := &RepoOptions{RequireSignature: true}
+
+	if err := verifyCommits(commits, signed); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("read without trusted keys, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(os.Getenv(SSH_ROOT), TRUSTED_KEYS), []byte(`[{"type":"ssh","fingerprint":"SHA256:test","key":"ssh-ed25519 AAAA"}]`), 0600)
+
+	if err := verifyCommits(commits, signed); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("read an unsigned commit, got %v", err)
+	}
+}
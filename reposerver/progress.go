@@ -0,0 +1,117 @@
+package reposerver
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// PROGRESS_BUFFER is the number of progress messages kept for a stream
+// which is slower than the remote.
+const PROGRESS_BUFFER = 64
+
+// Phases of a sync reported by SyncStream.
+const (
+	PHASE_RESOLVING    = "resolving"
+	PHASE_FETCHING     = "fetching"
+	PHASE_CHECKING_OUT = "checking out"
+	PHASE_SUBMODULES   = "updating submodules"
+	PHASE_DONE         = "done"
+)
+
+// progress relays the phases of a sync and the progress messages of the
+// remote, which go-git writes line by line, to the listeners of the sync.
+// Messages are logged as well.
+type progress struct {
+	key   string
+	hub   *progressHub
+	phase string
+	line  []byte
+}
+
+func (p *progress) Phase(phase string) {
+	if p == nil {
+		return
+	}
+
+	p.phase = phase
+	p.hub.publish(p.key, &SyncProgress{Phase: phase})
+}
+
+// Writer is where go-git writes the progress messages of the remote.
+func (p *progress) Writer() io.Writer {
+	if p == nil {
+		return os.Stdout
+	}
+
+	return io.MultiWriter(os.Stdout, p)
+}
+
+func (p *progress) Write(b []byte) (int, error) {
+	p.line = append(p.line, b...)
+
+	for {
+		i := bytes.IndexAny(p.line, "\r\n")
+
+		if i < 0 {
+			return len(b), nil
+		}
+
+		if message := string(bytes.TrimSpace(p.line[:i])); message != "" {
+			p.hub.publish(p.key, &SyncProgress{Phase: p.phase, Message: message})
+		}
+
+		p.line = p.line[i+1:]
+	}
+}
+
+// progressHub hands the progress of a sync to the streams waiting for it.
+// Streams joining a running sync get the progress from then on.
+type progressHub struct {
+	mu        sync.Mutex
+	next      int
+	listeners map[string]map[int]func(*SyncProgress)
+}
+
+func newProgressHub() *progressHub {
+	return &progressHub{listeners: map[string]map[int]func(*SyncProgress){}}
+}
+
+func (h *progressHub) subscribe(key string, listener func(*SyncProgress)) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listeners[key] == nil {
+		h.listeners[key] = map[int]func(*SyncProgress){}
+	}
+
+	id := h.next
+	h.next++
+	h.listeners[key][id] = listener
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.listeners[key], id)
+
+		if len(h.listeners[key]) == 0 {
+			delete(h.listeners, key)
+		}
+	}
+}
+
+func (h *progressHub) publish(key string, message *SyncProgress) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, listener := range h.listeners[key] {
+		listener(message)
+	}
+}
+
+// progress starts reporting a sync to the listeners of key.
+func (h *progressHub) progress(key string) *progress {
+	return &progress{key: key, hub: h}
+}
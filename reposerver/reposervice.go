@@ -1,28 +1,34 @@
 package reposerver
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/fs"
-	"os"
-	"path/filepath"
 	"strings"
 
+	"github.com/go-git/go-git/v5"
 	giturl "github.com/kubescape/go-git-url"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
-	structpb "google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	REPO_ROOT   = "REPO_ROOT"
 	SSH_ROOT    = "SSH_ROOT"
 	PRIVATE_KEY = "private_key"
+	CREDENTIALS = "credentials.json"
+
+	AUTH_NONE   = "none"
+	AUTH_BASIC  = "basic"
+	AUTH_SSH    = "ssh"
+	AUTH_HELPER = "helper"
 )
 
 type RepoService struct {
 	repoRoot string
 	sshRoot  string
+	secrets  SecretStore
+	locks    *checkoutLocks
+	progress *progressHub
 	UnimplementedRepoServiceServer
 }
 
@@ -38,12 +44,17 @@ func (s RepoService) Init() {
 	if err != nil {
 		log.Fatalln(err)
 	}
+
+	err = initKnownHosts()
+
+	if err != nil {
+		log.Fatalln(err)
+	}
 }
 
 func (s RepoService) RemoveSshKey(_ context.Context, request *RemoveSshKeyRequest) (*RemoveSshKeyResponse, error) {
-	sshRoot := os.Getenv(SSH_ROOT)
-	sshRootPath := filepath.Join(sshRoot, request.RepoId)
-	err := os.RemoveAll(sshRootPath)
+	err := s.secrets.Delete(request.RepoId)
+
 	if err != nil {
 		return nil, err
 	}
@@ -52,64 +63,195 @@ func (s RepoService) RemoveSshKey(_ context.Context, request *RemoveSshKeyReques
 }
 
 func (s RepoService) SaveSshKey(_ context.Context, request *SaveSshKeyRequest) (*SaveSshKeyResponse, error) {
-	var mode fs.FileMode = 0777
+	credentials, err := s.secrets.Get(request.RepoId)
+
+	if err != nil {
+		return nil, err
+	}
 
-	sshKey := request.SshKey
-	sshRoot := os.Getenv(SSH_ROOT)
-	sshRootPath := filepath.Join(sshRoot, request.RepoId)
-	sshPath := filepath.Join(sshRootPath, PRIVATE_KEY)
+	if credentials == nil {
+		credentials = &Credentials{Method: AUTH_SSH}
+	}
 
-	if _, err := os.Stat(sshRootPath); os.IsNotExist(err) {
-		err := os.Mkdir(sshRootPath, mode)
+	credentials.PrivateKey = []byte(request.SshKey)
+	err = s.secrets.Put(request.RepoId, *credentials)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SaveSshKeyResponse{}, nil
+}
+
+func (s RepoService) SaveCredentials(_ context.Context, request *SaveCredentialsRequest) (*SaveCredentialsResponse, error) {
+	credentials := Credentials{
+		Method:     request.Method,
+		Username:   request.Username,
+		Password:   request.Password,
+		Passphrase: request.SshPassphrase,
+		PrivateKey: []byte(request.SshKey),
+		Helper:     request.Helper,
+	}
+
+	if len(credentials.PrivateKey) == 0 {
+		stored, err := s.secrets.Get(request.RepoId)
 
 		if err != nil {
 			return nil, err
 		}
+
+		if stored != nil {
+			credentials.PrivateKey = stored.PrivateKey
+		}
 	}
 
-	fo, err := os.OpenFile(sshPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode)
+	switch request.Method {
+	case AUTH_NONE:
+	case AUTH_BASIC:
+		if credentials.Password == "" {
+			return nil, status.Error(codes.InvalidArgument, "basic authentication requires a password or token")
+		}
+	case AUTH_SSH:
+		if _, err := getPublicKey(credentials.PrivateKey, username(credentials), credentials.Passphrase); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ssh key: %v", err)
+		}
+	case AUTH_HELPER:
+		if err := allowedHelper(credentials.Helper); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown authentication method %q", request.Method)
+	}
+
+	err := s.secrets.Put(request.RepoId, credentials)
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		if err := fo.Close(); err != nil {
-			log.Errorln(err)
-		}
-	}()
+	return &SaveCredentialsResponse{}, nil
+}
+
+func (s RepoService) RemoveCredentials(ctx context.Context, request *RemoveCredentialsRequest) (*RemoveCredentialsResponse, error) {
+	_, err := s.RemoveSshKey(ctx, &RemoveSshKeyRequest{RepoId: request.RepoId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveCredentialsResponse{}, nil
+}
+
+func (s RepoService) ListHostKeys(_ context.Context, request *ListHostKeysRequest) (*ListHostKeysResponse, error) {
+	hostKeys, err := listHostKeys()
+
+	if err != nil {
+		return nil, err
+	}
 
-	data := []byte(sshKey)
-	err = fo.Truncate(0)
+	return &ListHostKeysResponse{HostKeys: hostKeys}, nil
+}
+
+func (s RepoService) AddHostKey(_ context.Context, request *AddHostKeyRequest) (*AddHostKeyResponse, error) {
+	err := addHostKey(request.Line)
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid host key: %v", err)
+	}
+
+	return &AddHostKeyResponse{}, nil
+}
+
+func (s RepoService) ApproveHostKey(_ context.Context, request *HostKeyRequest) (*HostKeyResponse, error) {
+	err := approveHostKey(request.Host, request.Fingerprint)
+
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &HostKeyResponse{}, nil
+}
+
+func (s RepoService) RemoveHostKey(_ context.Context, request *HostKeyRequest) (*HostKeyResponse, error) {
+	err := removeHostKey(request.Host, request.Fingerprint)
 
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = fo.Seek(0, 0)
+	return &HostKeyResponse{}, nil
+}
+
+func (s RepoService) ListTrustedKeys(_ context.Context, request *ListTrustedKeysRequest) (*ListTrustedKeysResponse, error) {
+	keys, err := readTrustedKeys()
 
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = fo.Write(data)
+	response := ListTrustedKeysResponse{}
+
+	for _, key := range keys {
+		response.TrustedKeys = append(response.TrustedKeys, &TrustedKey{Type: key.Type, Fingerprint: key.Fingerprint, Identity: key.Identity})
+	}
+
+	return &response, nil
+}
+
+func (s RepoService) AddTrustedKey(_ context.Context, request *AddTrustedKeyRequest) (*AddTrustedKeyResponse, error) {
+	key, err := addTrustedKey(request.Key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &SaveSshKeyResponse{}, nil
+	return &AddTrustedKeyResponse{TrustedKey: &TrustedKey{Type: key.Type, Fingerprint: key.Fingerprint, Identity: key.Identity}}, nil
+}
+
+func (s RepoService) RemoveTrustedKey(_ context.Context, request *RemoveTrustedKeyRequest) (*RemoveTrustedKeyResponse, error) {
+	err := removeTrustedKey(request.Fingerprint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveTrustedKeyResponse{}, nil
 }
 
 func (s RepoService) Sync(_ context.Context, syncRequest *SyncRequest) (*SyncResponse, error) {
 	repo := syncRequest.Repo
-	gitURL, err := giturl.NewGitURL(repo)
+	_, err := giturl.NewGitURL(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !repoIdPattern.MatchString(syncRequest.RepoId) {
+		return nil, status.Error(codes.InvalidArgument, "repoId is required")
+	}
+
+	if syncRequest.Sparse && syncRequest.Submodules {
+		return nil, status.Error(codes.InvalidArgument, "submodules are not supported for sparse checkouts")
+	}
+
+	options := syncOptions(syncRequest)
+	err = options.trust()
 
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := doSync(syncRequest.RepoId, repo, getFullRepoDir(s.repoRoot, gitURL))
+	repoDir := checkoutDir(s.repoRoot, syncRequest.RepoId, syncRequest.Ref)
+	err = s.syncCheckout(repo, syncRequest.RepoId, repoDir, syncRequest.Ref, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	release := s.locks.read(repoDir)
+	defer release()
+
+	r, err := git.PlainOpen(repoDir)
 
 	if err != nil {
 		return nil, err
@@ -127,78 +269,210 @@ func (s RepoService) Sync(_ context.Context, syncRequest *SyncRequest) (*SyncRes
 		return nil, err
 	}
 
+	applications, err := discoverApplications(commit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signer := ""
+
+	if options.signed {
+		signer, err = verifyCommit(commit, options.trustedKeys)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &SyncResponse{
-		Hash:   ref.Hash().String(),
-		Commit: commit.Message,
+		Hash:         ref.Hash().String(),
+		Commit:       commit.Message,
+		Ref:          syncRequest.Ref,
+		Applications: applications,
+		Signer:       signer,
 	}, nil
 }
 
-func (s RepoService) GetRepoDir(_ context.Context, repoDirRequest *RepoDirRequest) (*RepoDirResponse, error) {
-	repoDirResp := RepoDirResponse{}
-	gitURL, err := giturl.NewGitURL(repoDirRequest.RepoUrl)
+// DescribeCommit describes a commit of a checkout as its sync would, so the
+// server can promote it without moving the checkout.
+func (s RepoService) DescribeCommit(_ context.Context, request *CommitRequest) (*SyncResponse, error) {
+	if request.Hash == "" {
+		return nil, status.Error(codes.InvalidArgument, "hash is required")
+	}
+
+	commit, release, err := s.commitAt(request.Repo, request.RepoId, request.Ref, request.Options, request.Hash)
 
 	if err != nil {
-		log.Infoln(err)
+		return nil, err
 	}
 
-	repoDirResp.Path = fmt.Sprintf("%s-%s", gitURL.GetOwnerName(), gitURL.GetRepoName())
-	return &repoDirResp, nil
+	defer release()
+
+	applications, err := discoverApplications(commit.Commit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signer := ""
+
+	if request.RequireSignature {
+		keys, err := readTrustedKeys()
+
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err = verifyCommit(commit.Commit, keys)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &SyncResponse{
+		Hash:         commit.Hash.String(),
+		Commit:       commit.Message,
+		Ref:          request.Ref,
+		Applications: applications,
+		Signer:       signer,
+	}, nil
 }
 
-func (s RepoService) GetPaths(_ context.Context, pathsRequest *PathsRequest) (*PathsResponse, error) {
-	pathsResp := PathsResponse{
-		RepoRoot:   os.Getenv(REPO_ROOT),
-		SshRoot:    os.Getenv(SSH_ROOT),
-		PrivateKey: PRIVATE_KEY,
+// UpdateSparseCheckout checks out the directories of the applications of a
+// sparse checkout as they change, without fetching. Checkouts which were not
+// cloned yet, or with other options, are checked out by their next sync.
+func (s RepoService) UpdateSparseCheckout(_ context.Context, syncRequest *SyncRequest) (*SparseCheckoutResponse, error) {
+	if !repoIdPattern.MatchString(syncRequest.RepoId) {
+		return nil, status.Error(codes.InvalidArgument, "repoId is required")
 	}
-	return &pathsResp, nil
+
+	options := syncOptions(syncRequest)
+	repoDir := checkoutDir(s.repoRoot, syncRequest.RepoId, syncRequest.Ref)
+	dirs := options.sparseDirs()
+
+	err := s.locks.write(repoDir, "sparse "+repoDir+" "+strings.Join(dirs, ","), func() error {
+		r, err := git.PlainOpen(repoDir)
+
+		if err == git.ErrRepositoryNotExists {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !options.sparse || optionsChanged(r, options) {
+			return nil
+		}
+
+		head, err := r.Head()
+
+		if err != nil {
+			return err
+		}
+
+		w, err := r.Worktree()
+
+		if err != nil {
+			return err
+		}
+
+		err = options.unskipSparse(r)
+
+		if err != nil {
+			return err
+		}
+
+		checkoutOptions := git.CheckoutOptions{Hash: head.Hash(), Force: true, SparseCheckoutDirectories: dirs}
+
+		if head.Name().IsBranch() {
+			checkoutOptions = git.CheckoutOptions{Branch: head.Name(), Force: true, SparseCheckoutDirectories: dirs}
+		}
+
+		return w.Checkout(&checkoutOptions)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SparseCheckoutResponse{}, nil
 }
 
-func (s RepoService) GetManifests(_ context.Context, manifestsRequest *ManifestsRequest) (*ManifestsResponse, error) {
-	manifestResp := ManifestsResponse{}
-	rootDir := os.Getenv(REPO_ROOT)
-	repoRoot := filepath.Join(rootDir, manifestsRequest.Path)
-	files, err := os.ReadDir(repoRoot)
-	allowedFiles := map[string]string{
-		"data":     "",
-		"schema":   "",
-		"uischema": "",
-	}
-
-	for _, file := range files {
-		if !file.IsDir() {
-			var fullFileName = file.Name()
-			var baseFileName = strings.TrimSuffix(fullFileName, filepath.Ext(fullFileName))
-			_, ok := allowedFiles[baseFileName]
-
-			if ok {
-				var result map[string]interface{}
-				contents := readFile(filepath.Join(repoRoot, file.Name()))
-				json.Unmarshal([]byte(contents), &result)
-				details, err := structpb.NewStruct(result)
+// SyncStream syncs like Sync, streaming the phases of the sync and the
+// progress messages of the remote. The last message carries the result.
+func (s RepoService) SyncStream(syncRequest *SyncRequest, stream RepoService_SyncStreamServer) error {
+	messages := make(chan *SyncProgress, PROGRESS_BUFFER)
+	key := syncKey(syncRequest.Repo, checkoutDir(s.repoRoot, syncRequest.RepoId, syncRequest.Ref))
+	unsubscribe := s.progress.subscribe(key, func(message *SyncProgress) {
+		// Slow streams miss messages rather than holding up the sync.
+		select {
+		case messages <- message:
+		default:
+		}
+	})
 
-				if err != nil {
-					panic(err)
-				}
+	defer unsubscribe()
 
-				if baseFileName == "data" {
-					manifestResp.Data = details
-				}
+	type result struct {
+		response *SyncResponse
+		err      error
+	}
 
-				if baseFileName == "schema" {
-					manifestResp.Schema = details
-				}
+	done := make(chan result, 1)
+
+	go func() {
+		response, err := s.Sync(stream.Context(), syncRequest)
+		done <- result{response, err}
+	}()
+
+	for {
+		select {
+		case message := <-messages:
+			err := stream.Send(message)
+
+			if err != nil {
+				return err
+			}
+		case result := <-done:
+			if result.err != nil {
+				return result.err
+			}
+
+			for len(messages) > 0 {
+				err := stream.Send(<-messages)
 
-				if baseFileName == "uischema" {
-					manifestResp.UiSchema = details
+				if err != nil {
+					return err
 				}
 			}
+
+			return stream.Send(&SyncProgress{Phase: PHASE_DONE, Result: result.response})
+		case <-stream.Context().Done():
+			return stream.Context().Err()
 		}
 	}
+}
+
+func (s RepoService) GetSettings(_ context.Context, settingsRequest *SettingsRequest) (*SettingsResponse, error) {
+	keys, err := keyFingerprints(s.secrets)
 
 	if err != nil {
-		fmt.Println(err)
+		return nil, err
+	}
+
+	return &SettingsResponse{
+		SecretStore: s.secrets.Name(),
+		Keys:        keys,
+	}, nil
+}
+
+func (s RepoService) GetManifests(_ context.Context, manifestsRequest *ManifestsRequest) (*ManifestsResponse, error) {
+	if manifestsRequest.Repo == "" || manifestsRequest.RepoId == "" {
+		return nil, status.Error(codes.InvalidArgument, "repo and repoId are required")
 	}
 
-	return &manifestResp, nil
+	return s.getCommitManifests(manifestsRequest)
 }
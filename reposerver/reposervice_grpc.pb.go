@@ -23,11 +23,29 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RepoServiceClient interface {
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	SyncStream(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (RepoService_SyncStreamClient, error)
+	DescribeCommit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	UpdateSparseCheckout(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SparseCheckoutResponse, error)
 	SaveSshKey(ctx context.Context, in *SaveSshKeyRequest, opts ...grpc.CallOption) (*SaveSshKeyResponse, error)
 	RemoveSshKey(ctx context.Context, in *RemoveSshKeyRequest, opts ...grpc.CallOption) (*RemoveSshKeyResponse, error)
+	SaveCredentials(ctx context.Context, in *SaveCredentialsRequest, opts ...grpc.CallOption) (*SaveCredentialsResponse, error)
+	RemoveCredentials(ctx context.Context, in *RemoveCredentialsRequest, opts ...grpc.CallOption) (*RemoveCredentialsResponse, error)
+	ListHostKeys(ctx context.Context, in *ListHostKeysRequest, opts ...grpc.CallOption) (*ListHostKeysResponse, error)
+	AddHostKey(ctx context.Context, in *AddHostKeyRequest, opts ...grpc.CallOption) (*AddHostKeyResponse, error)
+	ApproveHostKey(ctx context.Context, in *HostKeyRequest, opts ...grpc.CallOption) (*HostKeyResponse, error)
+	RemoveHostKey(ctx context.Context, in *HostKeyRequest, opts ...grpc.CallOption) (*HostKeyResponse, error)
+	ListTrustedKeys(ctx context.Context, in *ListTrustedKeysRequest, opts ...grpc.CallOption) (*ListTrustedKeysResponse, error)
+	AddTrustedKey(ctx context.Context, in *AddTrustedKeyRequest, opts ...grpc.CallOption) (*AddTrustedKeyResponse, error)
+	RemoveTrustedKey(ctx context.Context, in *RemoveTrustedKeyRequest, opts ...grpc.CallOption) (*RemoveTrustedKeyResponse, error)
 	GetManifests(ctx context.Context, in *ManifestsRequest, opts ...grpc.CallOption) (*ManifestsResponse, error)
-	GetRepoDir(ctx context.Context, in *RepoDirRequest, opts ...grpc.CallOption) (*RepoDirResponse, error)
-	GetPaths(ctx context.Context, in *PathsRequest, opts ...grpc.CallOption) (*PathsResponse, error)
+	ValidateApplication(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	DiffApplication(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
+	GetTree(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*TreeResponse, error)
+	GetFile(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*FileResponse, error)
+	GetDocs(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*DocsResponse, error)
+	GetSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (*SettingsResponse, error)
+	CollectCheckouts(ctx context.Context, in *CollectRequest, opts ...grpc.CallOption) (*CollectResponse, error)
 }
 
 type repoServiceClient struct {
@@ -47,6 +65,56 @@ func (c *repoServiceClient) Sync(ctx context.Context, in *SyncRequest, opts ...g
 	return out, nil
 }
 
+func (c *repoServiceClient) SyncStream(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (RepoService_SyncStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RepoService_ServiceDesc.Streams[0], "/reposerver.RepoService/SyncStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &repoServiceSyncStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RepoService_SyncStreamClient interface {
+	Recv() (*SyncProgress, error)
+	grpc.ClientStream
+}
+
+type repoServiceSyncStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *repoServiceSyncStreamClient) Recv() (*SyncProgress, error) {
+	m := new(SyncProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *repoServiceClient) DescribeCommit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/DescribeCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) UpdateSparseCheckout(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SparseCheckoutResponse, error) {
+	out := new(SparseCheckoutResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/UpdateSparseCheckout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *repoServiceClient) SaveSshKey(ctx context.Context, in *SaveSshKeyRequest, opts ...grpc.CallOption) (*SaveSshKeyResponse, error) {
 	out := new(SaveSshKeyResponse)
 	err := c.cc.Invoke(ctx, "/reposerver.RepoService/SaveSshKey", in, out, opts...)
@@ -65,6 +133,87 @@ func (c *repoServiceClient) RemoveSshKey(ctx context.Context, in *RemoveSshKeyRe
 	return out, nil
 }
 
+func (c *repoServiceClient) SaveCredentials(ctx context.Context, in *SaveCredentialsRequest, opts ...grpc.CallOption) (*SaveCredentialsResponse, error) {
+	out := new(SaveCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/SaveCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) RemoveCredentials(ctx context.Context, in *RemoveCredentialsRequest, opts ...grpc.CallOption) (*RemoveCredentialsResponse, error) {
+	out := new(RemoveCredentialsResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/RemoveCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) ListHostKeys(ctx context.Context, in *ListHostKeysRequest, opts ...grpc.CallOption) (*ListHostKeysResponse, error) {
+	out := new(ListHostKeysResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/ListHostKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) AddHostKey(ctx context.Context, in *AddHostKeyRequest, opts ...grpc.CallOption) (*AddHostKeyResponse, error) {
+	out := new(AddHostKeyResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/AddHostKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) ApproveHostKey(ctx context.Context, in *HostKeyRequest, opts ...grpc.CallOption) (*HostKeyResponse, error) {
+	out := new(HostKeyResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/ApproveHostKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) RemoveHostKey(ctx context.Context, in *HostKeyRequest, opts ...grpc.CallOption) (*HostKeyResponse, error) {
+	out := new(HostKeyResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/RemoveHostKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) ListTrustedKeys(ctx context.Context, in *ListTrustedKeysRequest, opts ...grpc.CallOption) (*ListTrustedKeysResponse, error) {
+	out := new(ListTrustedKeysResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/ListTrustedKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) AddTrustedKey(ctx context.Context, in *AddTrustedKeyRequest, opts ...grpc.CallOption) (*AddTrustedKeyResponse, error) {
+	out := new(AddTrustedKeyResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/AddTrustedKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) RemoveTrustedKey(ctx context.Context, in *RemoveTrustedKeyRequest, opts ...grpc.CallOption) (*RemoveTrustedKeyResponse, error) {
+	out := new(RemoveTrustedKeyResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/RemoveTrustedKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *repoServiceClient) GetManifests(ctx context.Context, in *ManifestsRequest, opts ...grpc.CallOption) (*ManifestsResponse, error) {
 	out := new(ManifestsResponse)
 	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetManifests", in, out, opts...)
@@ -74,18 +223,72 @@ func (c *repoServiceClient) GetManifests(ctx context.Context, in *ManifestsReque
 	return out, nil
 }
 
-func (c *repoServiceClient) GetRepoDir(ctx context.Context, in *RepoDirRequest, opts ...grpc.CallOption) (*RepoDirResponse, error) {
-	out := new(RepoDirResponse)
-	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetRepoDir", in, out, opts...)
+func (c *repoServiceClient) ValidateApplication(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/ValidateApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) GetHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) DiffApplication(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/DiffApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) GetTree(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*TreeResponse, error) {
+	out := new(TreeResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetTree", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *repoServiceClient) GetPaths(ctx context.Context, in *PathsRequest, opts ...grpc.CallOption) (*PathsResponse, error) {
-	out := new(PathsResponse)
-	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetPaths", in, out, opts...)
+func (c *repoServiceClient) GetFile(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*FileResponse, error) {
+	out := new(FileResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) GetDocs(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*DocsResponse, error) {
+	out := new(DocsResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetDocs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) GetSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (*SettingsResponse, error) {
+	out := new(SettingsResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/GetSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repoServiceClient) CollectCheckouts(ctx context.Context, in *CollectRequest, opts ...grpc.CallOption) (*CollectResponse, error) {
+	out := new(CollectResponse)
+	err := c.cc.Invoke(ctx, "/reposerver.RepoService/CollectCheckouts", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -97,11 +300,29 @@ func (c *repoServiceClient) GetPaths(ctx context.Context, in *PathsRequest, opts
 // for forward compatibility
 type RepoServiceServer interface {
 	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	SyncStream(*SyncRequest, RepoService_SyncStreamServer) error
+	DescribeCommit(context.Context, *CommitRequest) (*SyncResponse, error)
+	UpdateSparseCheckout(context.Context, *SyncRequest) (*SparseCheckoutResponse, error)
 	SaveSshKey(context.Context, *SaveSshKeyRequest) (*SaveSshKeyResponse, error)
 	RemoveSshKey(context.Context, *RemoveSshKeyRequest) (*RemoveSshKeyResponse, error)
+	SaveCredentials(context.Context, *SaveCredentialsRequest) (*SaveCredentialsResponse, error)
+	RemoveCredentials(context.Context, *RemoveCredentialsRequest) (*RemoveCredentialsResponse, error)
+	ListHostKeys(context.Context, *ListHostKeysRequest) (*ListHostKeysResponse, error)
+	AddHostKey(context.Context, *AddHostKeyRequest) (*AddHostKeyResponse, error)
+	ApproveHostKey(context.Context, *HostKeyRequest) (*HostKeyResponse, error)
+	RemoveHostKey(context.Context, *HostKeyRequest) (*HostKeyResponse, error)
+	ListTrustedKeys(context.Context, *ListTrustedKeysRequest) (*ListTrustedKeysResponse, error)
+	AddTrustedKey(context.Context, *AddTrustedKeyRequest) (*AddTrustedKeyResponse, error)
+	RemoveTrustedKey(context.Context, *RemoveTrustedKeyRequest) (*RemoveTrustedKeyResponse, error)
 	GetManifests(context.Context, *ManifestsRequest) (*ManifestsResponse, error)
-	GetRepoDir(context.Context, *RepoDirRequest) (*RepoDirResponse, error)
-	GetPaths(context.Context, *PathsRequest) (*PathsResponse, error)
+	ValidateApplication(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	GetHistory(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	DiffApplication(context.Context, *DiffRequest) (*DiffResponse, error)
+	GetTree(context.Context, *ContentRequest) (*TreeResponse, error)
+	GetFile(context.Context, *ContentRequest) (*FileResponse, error)
+	GetDocs(context.Context, *ContentRequest) (*DocsResponse, error)
+	GetSettings(context.Context, *SettingsRequest) (*SettingsResponse, error)
+	CollectCheckouts(context.Context, *CollectRequest) (*CollectResponse, error)
 	mustEmbedUnimplementedRepoServiceServer()
 }
 
@@ -112,20 +333,74 @@ type UnimplementedRepoServiceServer struct {
 func (UnimplementedRepoServiceServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
 }
+func (UnimplementedRepoServiceServer) SyncStream(*SyncRequest, RepoService_SyncStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SyncStream not implemented")
+}
+func (UnimplementedRepoServiceServer) DescribeCommit(context.Context, *CommitRequest) (*SyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeCommit not implemented")
+}
+func (UnimplementedRepoServiceServer) UpdateSparseCheckout(context.Context, *SyncRequest) (*SparseCheckoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSparseCheckout not implemented")
+}
 func (UnimplementedRepoServiceServer) SaveSshKey(context.Context, *SaveSshKeyRequest) (*SaveSshKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveSshKey not implemented")
 }
 func (UnimplementedRepoServiceServer) RemoveSshKey(context.Context, *RemoveSshKeyRequest) (*RemoveSshKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveSshKey not implemented")
 }
+func (UnimplementedRepoServiceServer) SaveCredentials(context.Context, *SaveCredentialsRequest) (*SaveCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveCredentials not implemented")
+}
+func (UnimplementedRepoServiceServer) RemoveCredentials(context.Context, *RemoveCredentialsRequest) (*RemoveCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCredentials not implemented")
+}
+func (UnimplementedRepoServiceServer) ListHostKeys(context.Context, *ListHostKeysRequest) (*ListHostKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHostKeys not implemented")
+}
+func (UnimplementedRepoServiceServer) AddHostKey(context.Context, *AddHostKeyRequest) (*AddHostKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddHostKey not implemented")
+}
+func (UnimplementedRepoServiceServer) ApproveHostKey(context.Context, *HostKeyRequest) (*HostKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveHostKey not implemented")
+}
+func (UnimplementedRepoServiceServer) RemoveHostKey(context.Context, *HostKeyRequest) (*HostKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveHostKey not implemented")
+}
+func (UnimplementedRepoServiceServer) ListTrustedKeys(context.Context, *ListTrustedKeysRequest) (*ListTrustedKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrustedKeys not implemented")
+}
+func (UnimplementedRepoServiceServer) AddTrustedKey(context.Context, *AddTrustedKeyRequest) (*AddTrustedKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTrustedKey not implemented")
+}
+func (UnimplementedRepoServiceServer) RemoveTrustedKey(context.Context, *RemoveTrustedKeyRequest) (*RemoveTrustedKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTrustedKey not implemented")
+}
 func (UnimplementedRepoServiceServer) GetManifests(context.Context, *ManifestsRequest) (*ManifestsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetManifests not implemented")
 }
-func (UnimplementedRepoServiceServer) GetRepoDir(context.Context, *RepoDirRequest) (*RepoDirResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRepoDir not implemented")
+func (UnimplementedRepoServiceServer) ValidateApplication(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateApplication not implemented")
+}
+func (UnimplementedRepoServiceServer) GetHistory(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedRepoServiceServer) DiffApplication(context.Context, *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffApplication not implemented")
+}
+func (UnimplementedRepoServiceServer) GetTree(context.Context, *ContentRequest) (*TreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTree not implemented")
 }
-func (UnimplementedRepoServiceServer) GetPaths(context.Context, *PathsRequest) (*PathsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPaths not implemented")
+func (UnimplementedRepoServiceServer) GetFile(context.Context, *ContentRequest) (*FileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedRepoServiceServer) GetDocs(context.Context, *ContentRequest) (*DocsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocs not implemented")
+}
+func (UnimplementedRepoServiceServer) GetSettings(context.Context, *SettingsRequest) (*SettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettings not implemented")
+}
+func (UnimplementedRepoServiceServer) CollectCheckouts(context.Context, *CollectRequest) (*CollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectCheckouts not implemented")
 }
 func (UnimplementedRepoServiceServer) mustEmbedUnimplementedRepoServiceServer() {}
 
@@ -158,6 +433,63 @@ func _RepoService_Sync_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepoService_SyncStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RepoServiceServer).SyncStream(m, &repoServiceSyncStreamServer{stream})
+}
+
+type RepoService_SyncStreamServer interface {
+	Send(*SyncProgress) error
+	grpc.ServerStream
+}
+
+type repoServiceSyncStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *repoServiceSyncStreamServer) Send(m *SyncProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RepoService_DescribeCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).DescribeCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/DescribeCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).DescribeCommit(ctx, req.(*CommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_UpdateSparseCheckout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).UpdateSparseCheckout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/UpdateSparseCheckout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).UpdateSparseCheckout(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RepoService_SaveSshKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SaveSshKeyRequest)
 	if err := dec(in); err != nil {
@@ -194,6 +526,168 @@ func _RepoService_RemoveSshKey_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepoService_SaveCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).SaveCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/SaveCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).SaveCredentials(ctx, req.(*SaveCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_RemoveCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).RemoveCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/RemoveCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).RemoveCredentials(ctx, req.(*RemoveCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_ListHostKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHostKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).ListHostKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/ListHostKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).ListHostKeys(ctx, req.(*ListHostKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_AddHostKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddHostKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).AddHostKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/AddHostKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).AddHostKey(ctx, req.(*AddHostKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_ApproveHostKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).ApproveHostKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/ApproveHostKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).ApproveHostKey(ctx, req.(*HostKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_RemoveHostKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).RemoveHostKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/RemoveHostKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).RemoveHostKey(ctx, req.(*HostKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_ListTrustedKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrustedKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).ListTrustedKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/ListTrustedKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).ListTrustedKeys(ctx, req.(*ListTrustedKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_AddTrustedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTrustedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).AddTrustedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/AddTrustedKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).AddTrustedKey(ctx, req.(*AddTrustedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_RemoveTrustedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTrustedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).RemoveTrustedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/RemoveTrustedKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).RemoveTrustedKey(ctx, req.(*RemoveTrustedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RepoService_GetManifests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ManifestsRequest)
 	if err := dec(in); err != nil {
@@ -212,38 +706,146 @@ func _RepoService_GetManifests_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RepoService_GetRepoDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RepoDirRequest)
+func _RepoService_ValidateApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RepoServiceServer).GetRepoDir(ctx, in)
+		return srv.(RepoServiceServer).ValidateApplication(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reposerver.RepoService/GetRepoDir",
+		FullMethod: "/reposerver.RepoService/ValidateApplication",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RepoServiceServer).GetRepoDir(ctx, req.(*RepoDirRequest))
+		return srv.(RepoServiceServer).ValidateApplication(ctx, req.(*ValidateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RepoService_GetPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PathsRequest)
+func _RepoService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RepoServiceServer).GetPaths(ctx, in)
+		return srv.(RepoServiceServer).GetHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/reposerver.RepoService/GetPaths",
+		FullMethod: "/reposerver.RepoService/GetHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RepoServiceServer).GetPaths(ctx, req.(*PathsRequest))
+		return srv.(RepoServiceServer).GetHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_DiffApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).DiffApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/DiffApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).DiffApplication(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_GetTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).GetTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/GetTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).GetTree(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_GetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).GetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/GetFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).GetFile(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_GetDocs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).GetDocs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/GetDocs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).GetDocs(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_GetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).GetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/GetSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).GetSettings(ctx, req.(*SettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepoService_CollectCheckouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepoServiceServer).CollectCheckouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reposerver.RepoService/CollectCheckouts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepoServiceServer).CollectCheckouts(ctx, req.(*CollectRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -259,6 +861,14 @@ var RepoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sync",
 			Handler:    _RepoService_Sync_Handler,
 		},
+		{
+			MethodName: "DescribeCommit",
+			Handler:    _RepoService_DescribeCommit_Handler,
+		},
+		{
+			MethodName: "UpdateSparseCheckout",
+			Handler:    _RepoService_UpdateSparseCheckout_Handler,
+		},
 		{
 			MethodName: "SaveSshKey",
 			Handler:    _RepoService_SaveSshKey_Handler,
@@ -267,19 +877,85 @@ var RepoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveSshKey",
 			Handler:    _RepoService_RemoveSshKey_Handler,
 		},
+		{
+			MethodName: "SaveCredentials",
+			Handler:    _RepoService_SaveCredentials_Handler,
+		},
+		{
+			MethodName: "RemoveCredentials",
+			Handler:    _RepoService_RemoveCredentials_Handler,
+		},
+		{
+			MethodName: "ListHostKeys",
+			Handler:    _RepoService_ListHostKeys_Handler,
+		},
+		{
+			MethodName: "AddHostKey",
+			Handler:    _RepoService_AddHostKey_Handler,
+		},
+		{
+			MethodName: "ApproveHostKey",
+			Handler:    _RepoService_ApproveHostKey_Handler,
+		},
+		{
+			MethodName: "RemoveHostKey",
+			Handler:    _RepoService_RemoveHostKey_Handler,
+		},
+		{
+			MethodName: "ListTrustedKeys",
+			Handler:    _RepoService_ListTrustedKeys_Handler,
+		},
+		{
+			MethodName: "AddTrustedKey",
+			Handler:    _RepoService_AddTrustedKey_Handler,
+		},
+		{
+			MethodName: "RemoveTrustedKey",
+			Handler:    _RepoService_RemoveTrustedKey_Handler,
+		},
 		{
 			MethodName: "GetManifests",
 			Handler:    _RepoService_GetManifests_Handler,
 		},
 		{
-			MethodName: "GetRepoDir",
-			Handler:    _RepoService_GetRepoDir_Handler,
+			MethodName: "ValidateApplication",
+			Handler:    _RepoService_ValidateApplication_Handler,
 		},
 		{
-			MethodName: "GetPaths",
-			Handler:    _RepoService_GetPaths_Handler,
+			MethodName: "GetHistory",
+			Handler:    _RepoService_GetHistory_Handler,
+		},
+		{
+			MethodName: "DiffApplication",
+			Handler:    _RepoService_DiffApplication_Handler,
+		},
+		{
+			MethodName: "GetTree",
+			Handler:    _RepoService_GetTree_Handler,
+		},
+		{
+			MethodName: "GetFile",
+			Handler:    _RepoService_GetFile_Handler,
+		},
+		{
+			MethodName: "GetDocs",
+			Handler:    _RepoService_GetDocs_Handler,
+		},
+		{
+			MethodName: "GetSettings",
+			Handler:    _RepoService_GetSettings_Handler,
+		},
+		{
+			MethodName: "CollectCheckouts",
+			Handler:    _RepoService_CollectCheckouts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SyncStream",
+			Handler:       _RepoService_SyncStream_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "reposerver/reposervice.proto",
 }
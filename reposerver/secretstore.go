@@ -0,0 +1,376 @@
+package reposerver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/infor-design/selfservice/pkg/secret"
+	"github.com/infor-design/selfservice/pkg/utils"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	SECRET_STORE     = "SECRET_STORE"
+	SECRET_NAMESPACE = "SECRET_NAMESPACE"
+	SECRETS_FILE     = "secrets.enc"
+
+	STORE_FILE       = "file"
+	STORE_KUBERNETES = "kubernetes"
+
+	secretPrefix   = "selfservice-repo-"
+	secretDataKey  = "credentials"
+	repoIdLabel    = "selfservice/repo-id"
+	namespaceFile  = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	secretSelector = "app.kubernetes.io/managed-by=selfservice"
+)
+
+// SecretStore keeps the credentials of repos, including their SSH private
+// keys. Credentials are only decrypted in memory when a repo is synced.
+type SecretStore interface {
+	Name() string
+	Get(repoId string) (*Credentials, error)
+	Put(repoId string, credentials Credentials) error
+	Delete(repoId string) error
+	List() ([]string, error)
+}
+
+// NewSecretStore returns the store selected by SECRET_STORE, an encrypted
+// file store under SSH_ROOT by default.
+func NewSecretStore() (SecretStore, error) {
+	switch store := utils.GetEnv(SECRET_STORE, STORE_FILE); store {
+	case STORE_FILE:
+		key, err := secret.Key()
+
+		if err == secret.ErrNoKey {
+			log.Warnf("%v, the credentials stored by earlier versions are read as is and new credentials cannot be saved until a key is set", err)
+		} else if err != nil {
+			return nil, err
+		}
+
+		return &fileSecretStore{root: os.Getenv(SSH_ROOT), key: key}, nil
+	case STORE_KUBERNETES:
+		restConfig, err := client.NewConfig().RestConfig()
+
+		if err != nil {
+			return nil, err
+		}
+
+		clientset, err := kubernetes.NewForConfig(restConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &kubeSecretStore{clientset: clientset, namespace: secretNamespace()}, nil
+	default:
+		return nil, errors.Errorf("unknown secret store %q", store)
+	}
+}
+
+// fileSecretStore envelope encrypts the credentials of each repo into
+// SSH_ROOT/{repoId}/secrets.enc, sealing them with a data key of their own
+// which is in turn sealed with the secret key. Without a secret key the
+// plaintext credentials of earlier versions are read in place, and are
+// migrated on the first read once a key is set.
+type fileSecretStore struct {
+	root string
+	key  []byte
+}
+
+func (f *fileSecretStore) Name() string {
+	return STORE_FILE
+}
+
+func (f *fileSecretStore) path(repoId string) string {
+	return filepath.Join(f.root, repoId, SECRETS_FILE)
+}
+
+func (f *fileSecretStore) Get(repoId string) (*Credentials, error) {
+	ciphertext, err := os.ReadFile(f.path(repoId))
+
+	if os.IsNotExist(err) {
+		return f.migrate(repoId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.key == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the credentials of repo %s are encrypted and %s is not set", repoId, secret.SECRET_KEY)
+	}
+
+	plaintext, err := secret.OpenEnvelope(f.key, ciphertext)
+
+	if err != nil {
+		// sealed directly with the secret key by earlier versions
+		plaintext, err = secret.Open(f.key, ciphertext)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to decrypt the credentials of repo %s", repoId)
+	}
+
+	return decodeCredentials(plaintext)
+}
+
+func (f *fileSecretStore) Put(repoId string, credentials Credentials) error {
+	if f.key == nil {
+		return status.Errorf(codes.FailedPrecondition, "%s must be set to save credentials", secret.SECRET_KEY)
+	}
+
+	plaintext, err := json.Marshal(credentials)
+
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := secret.SealEnvelope(f.key, plaintext)
+
+	if err != nil {
+		return err
+	}
+
+	dirPath := filepath.Join(f.root, repoId)
+	err = os.MkdirAll(dirPath, 0700)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(dirPath, 0700)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.path(repoId), ciphertext, 0600)
+}
+
+func (f *fileSecretStore) Delete(repoId string) error {
+	return os.RemoveAll(filepath.Join(f.root, repoId))
+}
+
+func (f *fileSecretStore) List() ([]string, error) {
+	entries, err := os.ReadDir(f.root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var repoIds []string
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			repoIds = append(repoIds, entry.Name())
+		}
+	}
+
+	return repoIds, nil
+}
+
+// migrate encrypts the plaintext credentials and private key written by
+// earlier versions, and removes them. They are returned as is while no secret
+// key is set.
+func (f *fileSecretStore) migrate(repoId string) (*Credentials, error) {
+	dirPath := filepath.Join(f.root, repoId)
+	credentials, err := readCredentials(dirPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := os.ReadFile(filepath.Join(dirPath, PRIVATE_KEY))
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if credentials == nil && len(privateKey) == 0 {
+		return nil, nil
+	}
+
+	if credentials == nil {
+		credentials = &Credentials{Method: AUTH_SSH}
+	}
+
+	credentials.PrivateKey = privateKey
+
+	if f.key == nil {
+		return credentials, nil
+	}
+
+	err = f.Put(repoId, *credentials)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{CREDENTIALS, PRIVATE_KEY} {
+		if err := os.Remove(filepath.Join(dirPath, name)); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	log.Infof("encrypted the stored credentials of repo %s", repoId)
+	return credentials, nil
+}
+
+// kubeSecretStore keeps the credentials of each repo in a Kubernetes Secret,
+// leaving encryption at rest to the cluster.
+type kubeSecretStore struct {
+	clientset kubernetes.Interface
+	namespace string
+}
+
+func (k *kubeSecretStore) Name() string {
+	return STORE_KUBERNETES
+}
+
+func (k *kubeSecretStore) Get(repoId string) (*Credentials, error) {
+	s, err := k.clientset.CoreV1().Secrets(k.namespace).Get(context.Background(), secretPrefix+repoId, metav1.GetOptions{})
+
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeCredentials(s.Data[secretDataKey])
+}
+
+func (k *kubeSecretStore) Put(repoId string, credentials Credentials) error {
+	data, err := json.Marshal(credentials)
+
+	if err != nil {
+		return err
+	}
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretPrefix + repoId,
+			Namespace: k.namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "selfservice",
+				repoIdLabel:                    repoId,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{secretDataKey: data},
+	}
+	secrets := k.clientset.CoreV1().Secrets(k.namespace)
+	_, err = secrets.Update(context.Background(), s, metav1.UpdateOptions{})
+
+	if apierrors.IsNotFound(err) {
+		_, err = secrets.Create(context.Background(), s, metav1.CreateOptions{})
+	}
+
+	return err
+}
+
+func (k *kubeSecretStore) Delete(repoId string) error {
+	err := k.clientset.CoreV1().Secrets(k.namespace).Delete(context.Background(), secretPrefix+repoId, metav1.DeleteOptions{})
+
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (k *kubeSecretStore) List() ([]string, error) {
+	list, err := k.clientset.CoreV1().Secrets(k.namespace).List(context.Background(), metav1.ListOptions{LabelSelector: secretSelector})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var repoIds []string
+
+	for _, s := range list.Items {
+		if repoId := s.Labels[repoIdLabel]; repoId != "" {
+			repoIds = append(repoIds, repoId)
+		}
+	}
+
+	return repoIds, nil
+}
+
+// secretNamespace defaults to the namespace of the reposerver pod.
+func secretNamespace() string {
+	if namespace := os.Getenv(SECRET_NAMESPACE); namespace != "" {
+		return namespace
+	}
+
+	if contents, err := os.ReadFile(namespaceFile); err == nil {
+		return strings.TrimSpace(string(contents))
+	}
+
+	return "default"
+}
+
+func decodeCredentials(data []byte) (*Credentials, error) {
+	var credentials Credentials
+	err := json.Unmarshal(data, &credentials)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &credentials, nil
+}
+
+// keyFingerprints lists the fingerprints of the SSH keys in the store, the
+// keys themselves never leave the reposerver.
+func keyFingerprints(store SecretStore) ([]*KeyFingerprint, error) {
+	repoIds, err := store.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(repoIds)
+	var fingerprints []*KeyFingerprint
+
+	for _, repoId := range repoIds {
+		credentials, err := store.Get(repoId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if credentials == nil || len(credentials.PrivateKey) == 0 {
+			continue
+		}
+
+		publicKey, err := getPublicKey(credentials.PrivateKey, username(*credentials), credentials.Passphrase)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "repo %s", repoId)
+		}
+
+		signer := publicKey.Signer.PublicKey()
+		fingerprints = append(fingerprints, &KeyFingerprint{
+			RepoId:      repoId,
+			KeyType:     signer.Type(),
+			Fingerprint: gossh.FingerprintSHA256(signer),
+		})
+	}
+
+	return fingerprints, nil
+}
@@ -0,0 +1,67 @@
+package reposerver
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSecretStoreEnvelope(t *testing.T) {
+	store := &fileSecretStore{root: t.TempDir(), key: bytes.Repeat([]byte{7}, 32)}
+	err := store.Put("1", Credentials{Method: AUTH_BASIC, Password: "s3cret"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := os.ReadFile(store.path("1"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(contents, []byte("s3cret")) {
+		t.Fatal("credentials are stored in plaintext")
+	}
+
+	credentials, err := store.Get("1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if credentials.Password != "s3cret" {
+		t.Fatalf("got password %q", credentials.Password)
+	}
+}
+
+func TestFileSecretStoreWithoutKey(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "1"), 0700)
+	os.WriteFile(filepath.Join(root, "1", CREDENTIALS), []byte(`{"method":"basic","password":"s3cret"}`), 0600)
+	store := &fileSecretStore{root: root}
+	credentials, err := store.Get("1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if credentials == nil || credentials.Password != "s3cret" {
+		t.Fatalf("got credentials %+v", credentials)
+	}
+
+	if err := store.Put("2", Credentials{Method: AUTH_NONE}); err == nil {
+		t.Fatal("saved credentials without a secret key")
+	}
+
+	store.key = bytes.Repeat([]byte{7}, 32)
+
+	if _, err := store.Get("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "1", CREDENTIALS)); !os.IsNotExist(err) {
+		t.Fatal("plaintext credentials were not migrated")
+	}
+}
@@ -1,13 +1,21 @@
 package reposerver
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
+	"regexp"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// repoIdPattern matches the ids of repos, which name their directories under
+// SSH_ROOT.
+var repoIdPattern = regexp.MustCompile(`^[0-9]+$`)
+
 func NewServer(config ServerConfig) *Server {
 	return &Server{
 		config,
@@ -20,11 +28,20 @@ func (s *Server) Run() {
 		log.Fatalf("Failed to listen to port 9000: %v", err)
 	}
 
+	secrets, err := NewSecretStore()
+
+	if err != nil {
+		log.Fatalf("Failed to open the secret store: %v", err)
+	}
+
 	service := RepoService{
 		repoRoot: os.Getenv(REPO_ROOT),
 		sshRoot:  os.Getenv(SSH_ROOT),
+		secrets:  secrets,
+		locks:    newCheckoutLocks(),
+		progress: newProgressHub(),
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(checkRepoId), grpc.StreamInterceptor(checkStreamRepoId))
 
 	service.Init()
 	RegisterRepoServiceServer(grpcServer, &service)
@@ -33,3 +50,39 @@ func (s *Server) Run() {
 		log.Fatalf("failed to serve gRPC server over port 9000: %v", err)
 	}
 }
+
+// checkRepoId refuses requests whose repo id could address files outside of
+// the directory of the repo.
+func checkRepoId(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := validRepoId(req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// checkStreamRepoId checks the repo id of the requests of streaming calls as
+// they are received.
+func checkStreamRepoId(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &repoIdStream{ss})
+}
+
+type repoIdStream struct {
+	grpc.ServerStream
+}
+
+func (s *repoIdStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return validRepoId(m)
+}
+
+func validRepoId(req interface{}) error {
+	if r, ok := req.(interface{ GetRepoId() string }); ok && r.GetRepoId() != "" && !repoIdPattern.MatchString(r.GetRepoId()) {
+		return status.Errorf(codes.InvalidArgument, "invalid repo id %q", r.GetRepoId())
+	}
+
+	return nil
+}
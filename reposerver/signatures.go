@@ -0,0 +1,309 @@
+package reposerver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	gossh "golang.org/x/crypto/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	TRUSTED_KEYS = "trusted_keys.json"
+
+	KEY_GPG = "gpg"
+	KEY_SSH = "ssh"
+
+	// SSHSIG_NAMESPACE is the namespace git signs commits in with ssh keys.
+	SSHSIG_NAMESPACE = "git"
+	// SSHSIG_VERSION is the only version of the SSHSIG format.
+	SSHSIG_VERSION = 1
+)
+
+var trustedKeysLock sync.Mutex
+
+// trustedKey is a GPG or SSH public key whose signatures are trusted on
+// commits of repos requiring signed commits.
+type trustedKey struct {
+	Type        string `json:"type"`
+	Fingerprint string `json:"fingerprint"`
+	Identity    string `json:"identity"`
+	Key         string `json:"key"`
+}
+
+func (k trustedKey) signer() string {
+	if k.Identity == "" {
+		return fmt.Sprintf("%s %s", k.Type, k.Fingerprint)
+	}
+
+	return fmt.Sprintf("%s %s (%s)", k.Type, k.Identity, k.Fingerprint)
+}
+
+func readTrustedKeys() ([]trustedKey, error) {
+	trustedKeysLock.Lock()
+	defer trustedKeysLock.Unlock()
+
+	return readTrustedKeysFile()
+}
+
+func readTrustedKeysFile() ([]trustedKey, error) {
+	contents, err := os.ReadFile(filepath.Join(os.Getenv(SSH_ROOT), TRUSTED_KEYS))
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []trustedKey
+	err = json.Unmarshal(contents, &keys)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", TRUSTED_KEYS)
+	}
+
+	return keys, nil
+}
+
+func writeTrustedKeysFile(keys []trustedKey) error {
+	contents, err := json.MarshalIndent(keys, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(os.Getenv(SSH_ROOT), TRUSTED_KEYS), contents, 0600)
+}
+
+// parseTrustedKey parses an armored GPG public key or an SSH public key in
+// authorized_keys format.
+func parseTrustedKey(key string) (*trustedKey, error) {
+	key = strings.TrimSpace(key)
+
+	if strings.HasPrefix(key, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid GPG key: %s", err)
+		}
+
+		if len(entities) != 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "expected one GPG key, got %d", len(entities))
+		}
+
+		var identities []string
+
+		for name := range entities[0].Identities {
+			identities = append(identities, name)
+		}
+
+		sort.Strings(identities)
+
+		trusted := trustedKey{
+			Type:        KEY_GPG,
+			Fingerprint: strings.ToUpper(hex.EncodeToString(entities[0].PrimaryKey.Fingerprint)),
+			Key:         key,
+		}
+
+		if len(identities) > 0 {
+			trusted.Identity = identities[0]
+		}
+
+		return &trusted, nil
+	}
+
+	publicKey, comment, _, _, err := gossh.ParseAuthorizedKey([]byte(key))
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "key is neither an armored GPG public key nor an SSH public key: %s", err)
+	}
+
+	return &trustedKey{
+		Type:        KEY_SSH,
+		Fingerprint: gossh.FingerprintSHA256(publicKey),
+		Identity:    comment,
+		Key:         key,
+	}, nil
+}
+
+func addTrustedKey(key string) (*trustedKey, error) {
+	trusted, err := parseTrustedKey(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trustedKeysLock.Lock()
+	defer trustedKeysLock.Unlock()
+
+	keys, err := readTrustedKeysFile()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range keys {
+		if existing.Fingerprint == trusted.Fingerprint {
+			return &existing, nil
+		}
+	}
+
+	return trusted, writeTrustedKeysFile(append(keys, *trusted))
+}
+
+func removeTrustedKey(fingerprint string) error {
+	trustedKeysLock.Lock()
+	defer trustedKeysLock.Unlock()
+
+	keys, err := readTrustedKeysFile()
+
+	if err != nil {
+		return err
+	}
+
+	kept := keys[:0]
+
+	for _, key := range keys {
+		if key.Fingerprint != fingerprint {
+			kept = append(kept, key)
+		}
+	}
+
+	if len(kept) == len(keys) {
+		return status.Errorf(codes.NotFound, "no trusted key %s", fingerprint)
+	}
+
+	return writeTrustedKeysFile(kept)
+}
+
+// verifyCommit checks the GPG or SSH signature of a commit against the
+// trusted keys, returning the signer.
+func verifyCommit(commit *object.Commit, keys []trustedKey) (string, error) {
+	signature := strings.TrimSpace(commit.PGPSignature)
+
+	if signature == "" {
+		return "", status.Errorf(codes.FailedPrecondition, "commit %s is not signed", commit.Hash)
+	}
+
+	if strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----") {
+		return verifySshSignature(commit, signature, keys)
+	}
+
+	for _, key := range keys {
+		if key.Type != KEY_GPG {
+			continue
+		}
+
+		if _, err := commit.Verify(key.Key); err == nil {
+			return key.signer(), nil
+		}
+	}
+
+	return "", status.Errorf(codes.FailedPrecondition, "commit %s is not signed by a trusted key", commit.Hash)
+}
+
+// verifySshSignature verifies a signature in the SSHSIG format ssh-keygen
+// signs commits in for git.
+func verifySshSignature(commit *object.Commit, signature string, keys []trustedKey) (string, error) {
+	invalid := status.Errorf(codes.FailedPrecondition, "commit %s has an invalid ssh signature", commit.Hash)
+	block, _ := pem.Decode([]byte(signature))
+
+	if block == nil || block.Type != "SSH SIGNATURE" || !bytes.HasPrefix(block.Bytes, []byte("SSHSIG")) {
+		return "", invalid
+	}
+
+	var sshsig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+
+	if gossh.Unmarshal(block.Bytes[len("SSHSIG"):], &sshsig) != nil || sshsig.Version != SSHSIG_VERSION || sshsig.Namespace != SSHSIG_NAMESPACE {
+		return "", invalid
+	}
+
+	publicKey, err := gossh.ParsePublicKey(sshsig.PublicKey)
+
+	if err != nil {
+		return "", invalid
+	}
+
+	var trusted *trustedKey
+
+	for i, key := range keys {
+		if key.Type == KEY_SSH && key.Fingerprint == gossh.FingerprintSHA256(publicKey) {
+			trusted = &keys[i]
+		}
+	}
+
+	if trusted == nil {
+		return "", status.Errorf(codes.FailedPrecondition, "commit %s is not signed by a trusted key", commit.Hash)
+	}
+
+	var h hash.Hash
+
+	switch sshsig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return "", invalid
+	}
+
+	encoded := &plumbing.MemoryObject{}
+	err = commit.EncodeWithoutSignature(encoded)
+
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := encoded.Reader()
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(h, reader)
+
+	if err != nil {
+		return "", err
+	}
+
+	signed := append([]byte("SSHSIG"), gossh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{sshsig.Namespace, sshsig.Reserved, sshsig.HashAlgorithm, h.Sum(nil)})...)
+
+	var sig gossh.Signature
+
+	if gossh.Unmarshal(sshsig.Signature, &sig) != nil || publicKey.Verify(signed, &sig) != nil {
+		return "", invalid
+	}
+
+	return trusted.signer(), nil
+}
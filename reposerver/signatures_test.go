@@ -0,0 +1,202 @@
+package reposerver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func headCommit(t *testing.T, dir string) *object.Commit {
+	t.Helper()
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := r.Head()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return commit
+}
+
+// sshSignedCommit commits with a new ssh key, returning the commit and the
+// public key.
+func sshSignedCommit(t *testing.T) (*object.Commit, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not installed")
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	output, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "alice@example.com", "-f", keyFile).CombinedOutput()
+
+	if err != nil {
+		t.Fatalf("ssh-keygen: %s: %s", err, output)
+	}
+
+	publicKey, err := os.ReadFile(keyFile + ".pub")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "-c", "gpg.format=ssh", "-c", "user.signingkey="+keyFile, "commit", "-q", "-S", "--allow-empty", "-m", "signed")
+	return headCommit(t, dir), string(publicKey)
+}
+
+// gpgSignedCommit commits with a new OpenPGP key, returning the commit and the
+// armored public key.
+func gpgSignedCommit(t *testing.T) (*object.Commit, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg is not installed")
+	}
+
+	// The agent socket must fit in a unix socket path.
+	home, err := os.MkdirTemp("", "gpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GNUPGHOME", home)
+	t.Cleanup(func() {
+		exec.Command("gpgconf", "--kill", "gpg-agent").Run()
+		os.RemoveAll(home)
+	})
+
+	output, err := exec.Command("gpg", "--batch", "--passphrase", "", "--quick-gen-key", "Bob <bob@example.com>", "ed25519", "sign", "never").CombinedOutput()
+
+	if err != nil {
+		t.Fatalf("gpg: %s: %s", err, output)
+	}
+
+	publicKey, err := exec.Command("gpg", "--armor", "--export", "bob@example.com").Output()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "-c", "user.signingkey=bob@example.com", "commit", "-q", "-S", "--allow-empty", "-m", "signed")
+	return headCommit(t, dir), string(publicKey)
+}
+
+func trusted(t *testing.T, keys ...string) []trustedKey {
+	t.Helper()
+	var parsed []trustedKey
+
+	for _, key := range keys {
+		trustedKey, err := parseTrustedKey(key)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed = append(parsed, *trustedKey)
+	}
+
+	return parsed
+}
+
+func TestVerifySshSignedCommit(t *testing.T) {
+	commit, publicKey := sshSignedCommit(t)
+	keys := trusted(t, publicKey)
+	signer, err := verifyCommit(commit, keys)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signer != "ssh alice@example.com ("+keys[0].Fingerprint+")" {
+		t.Errorf("got signer %q", signer)
+	}
+
+	_, otherKey := sshSignedCommit(t)
+
+	if _, err := verifyCommit(commit, trusted(t, otherKey)); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("verified with an untrusted key, got %v", err)
+	}
+
+	tampered := *commit
+	tampered.Message = "tampered"
+
+	if _, err := verifyCommit(&tampered, keys); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("verified a tampered commit, got %v", err)
+	}
+}
+
+func TestVerifySshSignatureVersion(t *testing.T) {
+	commit, publicKey := sshSignedCommit(t)
+	block, _ := pem.Decode([]byte(commit.PGPSignature))
+
+	if block == nil || binary.BigEndian.Uint32(block.Bytes[len("SSHSIG"):]) != SSHSIG_VERSION {
+		t.Fatalf("unexpected signature %s", commit.PGPSignature)
+	}
+
+	binary.BigEndian.PutUint32(block.Bytes[len("SSHSIG"):], 2)
+	changed := *commit
+	changed.PGPSignature = string(pem.EncodeToMemory(block))
+	_, err := verifyCommit(&changed, trusted(t, publicKey))
+
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "invalid ssh signature") {
+		t.Fatalf("verified a signature of version 2, got %v", err)
+	}
+}
+
+func TestVerifyGpgSignedCommit(t *testing.T) {
+	commit, publicKey := gpgSignedCommit(t)
+	keys := trusted(t, publicKey)
+	signer, err := verifyCommit(commit, keys)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signer != "gpg Bob <bob@example.com> ("+keys[0].Fingerprint+")" {
+		t.Errorf("got signer %q", signer)
+	}
+
+	// Keys of the other type are not tried.
+	_, sshKey := sshSignedCommit(t)
+
+	if _, err := verifyCommit(commit, trusted(t, sshKey)); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("verified with an untrusted key, got %v", err)
+	}
+
+	tampered := *commit
+	tampered.Message = "tampered"
+
+	if _, err := verifyCommit(&tampered, keys); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("verified a tampered commit, got %v", err)
+	}
+
+	if !bytes.Contains([]byte(commit.PGPSignature), []byte("BEGIN PGP SIGNATURE")) {
+		t.Errorf("got signature %s", commit.PGPSignature)
+	}
+}
@@ -0,0 +1,330 @@
+package reposerver
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MAX_SUBMODULE_DEPTH limits the nesting of submodules which are updated.
+const MAX_SUBMODULE_DEPTH = 10
+
+// checkoutCommit is a commit of a checkout. Its files are read from the git
+// objects of the checkout, or of the submodule they are in, and from the LFS
+// objects of the checkout.
+type checkoutCommit struct {
+	*object.Commit
+	gitDir string
+	lfs    *lfsStore
+}
+
+// locate finds the commit of the innermost submodule a path is in, and the
+// path within that submodule.
+func (c *checkoutCommit) locate(p string) (*checkoutCommit, string, error) {
+	if p == "" {
+		return c, p, nil
+	}
+
+	tree, err := c.Tree()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	parts := strings.Split(p, "/")
+
+	for i := 1; i <= len(parts); i++ {
+		prefix := strings.Join(parts[:i], "/")
+		entry, err := tree.FindEntry(prefix)
+
+		if err != nil {
+			break
+		}
+
+		if entry.Mode != filemode.Submodule {
+			continue
+		}
+
+		sub, err := c.submodule(prefix, entry.Hash)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return sub.locate(strings.Join(parts[i:], "/"))
+	}
+
+	return c, p, nil
+}
+
+// dirTree is the tree of a directory, which may be in a submodule.
+func (c *checkoutCommit) dirTree(dir string) (*object.Tree, error) {
+	owner, rel, err := c.locate(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := owner.Tree()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err = subtree(tree, rel)
+
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "directory %s not found at %s", dir, c.Hash)
+	}
+
+	return tree, nil
+}
+
+// submodule opens the commit of the submodule at subPath from the repo git
+// keeps for it in the modules directory of the checkout.
+func (c *checkoutCommit) submodule(subPath string, hash plumbing.Hash) (*checkoutCommit, error) {
+	name, err := c.submoduleName(subPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gitDir := filepath.Join(c.gitDir, "modules", filepath.FromSlash(name))
+	r, err := git.PlainOpen(gitDir)
+
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is a submodule, which is not checked out as submodules are not enabled for the repo", subPath)
+	}
+
+	commit, err := r.CommitObject(hash)
+
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "commit %s of submodule %s is not fetched, sync the repo", hash, subPath)
+	}
+
+	return &checkoutCommit{
+		Commit: commit,
+		gitDir: gitDir,
+		lfs:    c.lfs.submodule(gitDir, originUrl(r)),
+	}, nil
+}
+
+// submoduleName is the name of the submodule at subPath in the .gitmodules of
+// the commit, which defaults to its path.
+func (c *checkoutCommit) submoduleName(subPath string) (string, error) {
+	name := subPath
+
+	if file, err := c.File(".gitmodules"); err == nil {
+		content, err := file.Contents()
+
+		if err != nil {
+			return "", err
+		}
+
+		modules := config.NewModules()
+		err = modules.Unmarshal([]byte(content))
+
+		if err != nil {
+			return "", status.Errorf(codes.FailedPrecondition, ".gitmodules: %s", err)
+		}
+
+		for _, module := range modules.Submodules {
+			if module.Path == subPath {
+				name = module.Name
+			}
+		}
+	}
+
+	cleaned, err := RepoPath(name)
+
+	if err != nil || cleaned != name {
+		return "", status.Errorf(codes.FailedPrecondition, "invalid submodule name %q", name)
+	}
+
+	return name, nil
+}
+
+// updateSubmodules initializes and checks out the submodules of a checkout,
+// and theirs up to MAX_SUBMODULE_DEPTH. Relative submodule urls are resolved
+// against parentUrl, the url of the repo or submodule containing them. Each
+// submodule is fetched with the credentials of the repo when they suit it.
+func updateSubmodules(secrets SecretStore, repoId string, repoUrl string, parentUrl string, r *git.Repository, p *progress, depth int) error {
+	if depth >= MAX_SUBMODULE_DEPTH {
+		return nil
+	}
+
+	w, err := r.Worktree()
+
+	if err != nil {
+		return err
+	}
+
+	submodules, err := w.Submodules()
+
+	if err != nil {
+		return err
+	}
+
+	for _, submodule := range submodules {
+		moduleUrl, err := submoduleUrl(parentUrl, submodule.Config().URL)
+
+		if err != nil {
+			return errors.Wrapf(err, "submodule %s", submodule.Config().Path)
+		}
+
+		auth, err := reuseAuth(secrets, repoId, repoUrl, moduleUrl)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("updating submodule %s from %s", submodule.Config().Path, moduleUrl)
+		fmt.Fprintf(p.Writer(), "Submodule %s: %s\n", submodule.Config().Path, moduleUrl)
+
+		// go-git resolves relative urls but cannot parse scp-like ones, the
+		// resolved url is used for new submodules and ones whose url changed.
+		submodule.Config().URL = moduleUrl
+		err = setSubmoduleUrl(submodule, moduleUrl)
+
+		if err != nil {
+			return err
+		}
+
+		err = submodule.Update(&git.SubmoduleUpdateOptions{Init: true, Auth: auth})
+
+		if err != nil {
+			return errors.Wrapf(err, "submodule %s", submodule.Config().Path)
+		}
+
+		sr, err := submodule.Repository()
+
+		if err != nil {
+			return err
+		}
+
+		err = updateSubmodules(secrets, repoId, repoUrl, moduleUrl, sr, p, depth+1)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setSubmoduleUrl points the remote of an initialized submodule at its
+// current url.
+func setSubmoduleUrl(submodule *git.Submodule, moduleUrl string) error {
+	sr, err := submodule.Repository()
+
+	if err == git.ErrSubmoduleNotInitialized {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cfg, err := sr.Config()
+
+	if err != nil {
+		return err
+	}
+
+	remote, ok := cfg.Remotes[git.DefaultRemoteName]
+
+	if !ok || (len(remote.URLs) == 1 && remote.URLs[0] == moduleUrl) {
+		return nil
+	}
+
+	remote.URLs = []string{moduleUrl}
+	return sr.SetConfig(cfg)
+}
+
+// submoduleUrl resolves the url of a submodule, which may be relative to the
+// url of its parent. Scp-like urls are turned into ssh:// urls. Only http,
+// https and ssh urls are allowed, so a repo cannot check out local paths such
+// as the checkouts of other repos as its submodules.
+func submoduleUrl(parentUrl string, moduleUrl string) (string, error) {
+	resolved := moduleUrl
+
+	if strings.HasPrefix(moduleUrl, "./") || strings.HasPrefix(moduleUrl, "../") {
+		parent, err := transport.NewEndpoint(parentUrl)
+
+		if err != nil {
+			return "", err
+		}
+
+		parent.Path = path.Join(parent.Path, moduleUrl)
+		resolved = parent.String()
+	}
+
+	endpoint, err := transport.NewEndpoint(resolved)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch endpoint.Protocol {
+	case "ssh":
+		return endpoint.String(), nil
+	case "http", "https":
+		return resolved, nil
+	}
+
+	return "", errors.Errorf("submodule url %s uses %s, only http, https and ssh are allowed", moduleUrl, endpoint.Protocol)
+}
+
+// reuseAuth reuses the credentials of a repo for a submodule or LFS server
+// when they suit its protocol. Passwords and ssh keys are only sent to the
+// host of the repo.
+func reuseAuth(secrets SecretStore, repoId string, repoUrl string, targetUrl string) (transport.AuthMethod, error) {
+	repoEndpoint, err := transport.NewEndpoint(repoUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	targetEndpoint, err := transport.NewEndpoint(targetUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := getAuth(secrets, repoId, targetUrl)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", targetUrl)
+	}
+
+	switch auth.(type) {
+	case *ssh.PublicKeys:
+		if targetEndpoint.Protocol == "ssh" && targetEndpoint.Host == repoEndpoint.Host {
+			return auth, nil
+		}
+	case *http.BasicAuth:
+		if targetEndpoint.Protocol != "ssh" && targetEndpoint.Host == repoEndpoint.Host {
+			return auth, nil
+		}
+	}
+
+	if targetEndpoint.Protocol == "ssh" {
+		return nil, errors.Errorf("%s uses ssh, which requires an ssh key while the key of the repo is only sent to %s", targetUrl, repoEndpoint.Host)
+	}
+
+	return nil, nil
+}
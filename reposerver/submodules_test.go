@@ -0,0 +1,94 @@
+package reposerver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestSubmoduleUrl(t *testing.T) {
+	tests := []struct {
+		parent string
+		module string
+		want   string
+	}{
+		{"https://git.example.com/org/repo.git", "../module.git", "https://git.example.com/org/module.git"},
+		{"https://git.example.com/org/repo.git", "./nested.git", "https://git.example.com/org/repo.git/nested.git"},
+		{"https://git.example.com/org/repo.git", "https://other.example.com/module.git", "https://other.example.com/module.git"},
+		{"ssh://git@git.example.com/org/repo.git", "../module.git", "ssh://git@git.example.com/org/module.git"},
+		{"https://git.example.com/org/repo.git", "git@git.example.com:org/module.git", "ssh://git@git.example.com/org/module.git"},
+	}
+
+	for _, test := range tests {
+		got, err := submoduleUrl(test.parent, test.module)
+
+		if err != nil || got != test.want {
+			t.Errorf("submoduleUrl(%s, %s) = %s, %v, want %s", test.parent, test.module, got, err, test.want)
+		}
+	}
+
+	for _, module := range []string{"/srv/repos/1", "file:///srv/repos/1", "git://git.example.com/module.git"} {
+		if got, err := submoduleUrl("https://git.example.com/org/repo.git", module); err == nil {
+			t.Errorf("submoduleUrl accepted %s as %s", module, got)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	root := t.TempDir()
+	module := filepath.Join(root, "module")
+	runGit(t, root, "init", "-q", "-b", "main", module)
+	os.WriteFile(filepath.Join(module, "values.json"), []byte(`{}`), 0644)
+	runGit(t, module, "add", "-A")
+	runGit(t, module, "commit", "-q", "-m", "module")
+
+	dir := filepath.Join(root, "repo")
+	runGit(t, root, "init", "-q", "-b", "main", dir)
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "data.json"), []byte(`{}`), 0644)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "-c", "protocol.file.allow=always", "submodule", "add", "-q", module, "vendor/module")
+	runGit(t, dir, "commit", "-q", "-m", "add module")
+
+	r, err := git.PlainOpen(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, _ := r.Head()
+	commit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &checkoutCommit{Commit: commit, gitDir: filepath.Join(dir, git.GitDirName)}
+	owner, rel, err := c.locate("app/data.json")
+
+	if err != nil || owner != c || rel != "app/data.json" {
+		t.Fatalf("locate of a file of the repo: %v, %s, %v", owner, rel, err)
+	}
+
+	owner, rel, err = c.locate("vendor/module/values.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if owner == c || rel != "values.json" || owner.gitDir != filepath.Join(c.gitDir, "modules", "vendor/module") {
+		t.Fatalf("locate of a file of the submodule: %s, %s", owner.gitDir, rel)
+	}
+
+	if _, err := owner.File(rel); err != nil {
+		t.Fatalf("file of the submodule: %s", err)
+	}
+
+	os.RemoveAll(filepath.Join(c.gitDir, "modules"))
+
+	if _, _, err := c.locate("vendor/module/values.json"); err == nil {
+		t.Fatal("located a file of a submodule which is not checked out")
+	}
+}
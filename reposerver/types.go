@@ -6,6 +6,17 @@ type ManifestResponses struct {
 	Schema    map[string]interface{} `json:"schema"`
 }
 
+// Credentials select how a repo is authenticated against its remote, and are
+// kept in the SecretStore together with the SSH private key.
+type Credentials struct {
+	Method     string `json:"method"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	Passphrase string `json:"passphrase"`
+	PrivateKey []byte `json:"private_key,omitempty"`
+	Helper     string `json:"helper,omitempty"`
+}
+
 type ServerConfig struct {
 	RootDir string
 }
@@ -1,23 +1,26 @@
 package reposerver
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
-	giturl "github.com/kubescape/go-git-url"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func readFile(filePath string) []byte {
-	contents, err := os.ReadFile(filePath)
-	if err != nil {
-		fmt.Println(err)
-	}
-
-	return contents
-}
+var unsafeRefChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
 
 func initDir(path string) error {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -28,66 +31,328 @@ func initDir(path string) error {
 	return nil
 }
 
-func getFullRepoDir(rootDir string, giturl giturl.IGitURL) string {
-	return fmt.Sprintf("%s/%s-%s", rootDir, giturl.GetOwnerName(), giturl.GetRepoName())
+// checkoutDir keeps a checkout per pinned ref next to the checkout of the
+// remote HEAD, so one application can stay on a tag while another follows a
+// branch. Checkouts are keyed by repo id, so repos with the same url but other
+// credentials or refs do not share them.
+func checkoutDir(rootDir string, repoId string, ref string) string {
+	repoDir := filepath.Join(rootDir, repoId)
+
+	if ref == "" {
+		return repoDir
+	}
+
+	return fmt.Sprintf("%s@%s", repoDir, unsafeRefChars.ReplaceAllString(ref, "_"))
 }
 
-func doSync(repoId string, repoUrl string, repoDir string) (*git.Repository, error) {
+func doSync(secrets SecretStore, repoId string, repoUrl string, repoDir string, ref string, options cloneOptions, p *progress) (*git.Repository, error) {
+	p.Phase(PHASE_RESOLVING)
+
+	// The url or the clone options of a repo may have been changed since it
+	// was cloned.
+	if r, err := git.PlainOpen(repoDir); err == nil && (originUrl(r) != repoUrl || optionsChanged(r, options)) {
+		log.Infof("%s changed to %s, cloning it again", repoDir, repoUrl)
+		err = os.RemoveAll(repoDir)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if _, err := os.Stat(repoDir); os.IsNotExist(err) {
-		r, err := cloneRepo(repoId, repoUrl, repoDir)
+		r, err := cloneRepo(secrets, repoId, repoUrl, repoDir, ref, options, p)
 
 		if err != nil {
+			os.RemoveAll(repoDir)
 			return nil, err
 		}
 
-		return r, nil
-	}
+		p.Phase(PHASE_CHECKING_OUT)
+		err = checkoutRef(r, ref, options)
 
-	if _, err := os.Stat(repoDir); !os.IsNotExist(err) {
-		r, err := pullRepo(repoId, repoDir)
+		// go-git skips the files outside the sparse directories by marking
+		// the entries of the index, which is empty after cloning, so only a
+		// second checkout removes them.
+		if err == nil && options.sparse {
+			err = checkoutRef(r, ref, options)
+		}
+
+		if err == nil {
+			err = finishCheckout(secrets, repoId, repoUrl, r, options, p)
+		}
 
 		if err != nil {
+			os.RemoveAll(repoDir)
 			return nil, err
 		}
 
 		return r, nil
 	}
 
-	return nil, nil
+	var r *git.Repository
+	var err error
+
+	// Pulls check out all files of any commit, sparse and signed checkouts
+	// are fetched and checked out instead.
+	if ref == "" && !options.noCheckout() {
+		r, err = pullRepo(secrets, repoId, repoUrl, repoDir, options, p)
+	} else {
+		r, err = fetchRepo(secrets, repoId, repoUrl, repoDir, options, p)
+
+		if err == nil {
+			p.Phase(PHASE_CHECKING_OUT)
+			err = checkoutRef(r, ref, options)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, finishCheckout(secrets, repoId, repoUrl, r, options, p)
+}
+
+// finishCheckout checks out the submodules of a synced checkout, and records
+// the options it was synced with.
+func finishCheckout(secrets SecretStore, repoId string, repoUrl string, r *git.Repository, options cloneOptions, p *progress) error {
+	if options.submodules {
+		p.Phase(PHASE_SUBMODULES)
+		err := updateSubmodules(secrets, repoId, repoUrl, repoUrl, r, p, 0)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return recordOptions(r, options)
+}
+
+// resolveRef resolves a branch, tag or (abbreviated) commit hash. Branches
+// are looked up on origin first, as local branches are not updated on fetch.
+func resolveRef(r *git.Repository, ref string) (plumbing.Hash, error) {
+	hash, err := r.ResolveRevision(plumbing.Revision(plumbing.NewRemoteReferenceName("origin", ref)))
+
+	if err != nil {
+		hash, err = r.ResolveRevision(plumbing.Revision(ref))
+	}
+
+	if err != nil {
+		return plumbing.ZeroHash, status.Errorf(codes.NotFound, "ref %s not found", ref)
+	}
+
+	return *hash, nil
+}
+
+func checkoutRef(r *git.Repository, ref string, options cloneOptions) error {
+	if ref == "" && !options.noCheckout() {
+		return nil
+	}
+
+	w, err := r.Worktree()
+
+	if err != nil {
+		return err
+	}
+
+	if ref == "" {
+		return checkoutHead(r, w, options)
+	}
+
+	hash, err := resolveRef(r, ref)
+
+	if err != nil {
+		return err
+	}
+
+	err = options.verify(r, hash)
+
+	if err != nil {
+		return err
+	}
+
+	err = options.unskipSparse(r)
+
+	if err != nil {
+		return err
+	}
+
+	return w.Checkout(&git.CheckoutOptions{Hash: hash, Force: true, SparseCheckoutDirectories: options.sparseDirs()})
+}
+
+// checkoutHead moves the checked out branch to the commit fetched from
+// origin, as a pull does, checking out only the sparse directories.
+func checkoutHead(r *git.Repository, w *git.Worktree, options cloneOptions) error {
+	head, err := r.Head()
+
+	if err != nil {
+		return err
+	}
+
+	remote, err := r.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
+
+	if err != nil {
+		return err
+	}
+
+	err = options.verify(r, remote.Hash())
+
+	if err != nil {
+		return err
+	}
+
+	err = r.Storer.SetReference(plumbing.NewHashReference(head.Name(), remote.Hash()))
+
+	if err != nil {
+		return err
+	}
+
+	err = options.unskipSparse(r)
+
+	if err != nil {
+		return err
+	}
+
+	return w.Checkout(&git.CheckoutOptions{Branch: head.Name(), Force: true, SparseCheckoutDirectories: options.sparseDirs()})
+}
+
+// hostKeyStatus reports host key verification failures as a failed
+// precondition, so they are told apart from other sync errors. The ssh
+// handshake formats the error of the host key callback into its own, so they
+// are recognized by their message too.
+func hostKeyStatus(err error) error {
+	var unknown *UnknownHostKeyError
+	var mismatch *HostKeyMismatchError
+
+	if err == nil || status.Code(err) != codes.Unknown {
+		return err
+	}
+
+	message := err.Error()
+
+	if errors.As(err, &unknown) || errors.As(err, &mismatch) || strings.Contains(message, HOST_KEY_UNTRUSTED) || strings.Contains(message, HOST_KEY_MISMATCH) {
+		return status.Error(codes.FailedPrecondition, message)
+	}
+
+	return err
+}
+
+// username defaults to "git", which hosting providers accept for SSH keys as
+// well as for access tokens.
+func username(credentials Credentials) string {
+	if credentials.Username != "" {
+		return credentials.Username
+	}
+
+	return "git"
 }
 
-func getPublicKey(dirPath string) (*ssh.PublicKeys, error) {
-	var publicKey *ssh.PublicKeys
-	sshPath := dirPath + "/" + PRIVATE_KEY
-	sshKey, err := os.ReadFile(sshPath)
+func readCredentials(dirPath string) (*Credentials, error) {
+	contents, err := os.ReadFile(filepath.Join(dirPath, CREDENTIALS))
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey, err = ssh.NewPublicKeys("git", sshKey, "")
+	var credentials Credentials
+	err = json.Unmarshal(contents, &credentials)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return publicKey, err
+	return &credentials, nil
 }
 
-func cloneRepo(repoId string, repoUrl string, repoDir string) (*git.Repository, error) {
+// getAuth resolves the authentication of a repo from its stored credentials.
+// Repos without credentials are accessed anonymously over HTTP(S).
+func getAuth(secrets SecretStore, repoId string, repoUrl string) (transport.AuthMethod, error) {
+	credentials, err := secrets.Get(repoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if credentials == nil {
+		credentials = &Credentials{Method: AUTH_NONE}
+	}
+
+	switch credentials.Method {
+	case AUTH_BASIC:
+		return &http.BasicAuth{
+			Username: username(*credentials),
+			Password: credentials.Password,
+		}, nil
+	case AUTH_SSH:
+		return getPublicKey(credentials.PrivateKey, username(*credentials), credentials.Passphrase)
+	case AUTH_HELPER:
+		return helperAuth(credentials.Helper, repoUrl)
+	}
+
+	endpoint, err := transport.NewEndpoint(repoUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Protocol == "ssh" {
+		return nil, errors.Errorf("no credentials configured for %s, ssh requires a private key", repoUrl)
+	}
+
+	return nil, nil
+}
+
+// getPublicKey parses the private key in memory, it is never written to disk
+// in plaintext. Hosts are verified against the trusted host keys.
+func getPublicKey(sshKey []byte, user string, passphrase string) (*ssh.PublicKeys, error) {
+	if len(sshKey) == 0 {
+		return nil, errors.New("no ssh private key is stored")
+	}
+
+	auth, err := ssh.NewPublicKeys(user, sshKey, passphrase)
+
+	if err != nil {
+		return nil, err
+	}
+
+	auth.HostKeyCallback = verifyHostKey
+	return auth, nil
+}
+
+func cloneRepo(secrets SecretStore, repoId string, repoUrl string, repoDir string, ref string, options cloneOptions, p *progress) (*git.Repository, error) {
 	log.Infof("cloning %s into %s", repoUrl, repoDir)
-	dirPath := os.Getenv(SSH_ROOT) + "/" + repoId
-	auth, keyErr := getPublicKey(dirPath)
+	auth, authErr := getAuth(secrets, repoId, repoUrl)
 
-	if keyErr != nil {
-		return nil, keyErr
+	if authErr != nil {
+		return nil, authErr
 	}
 
-	r, err := git.PlainClone(repoDir, false, &git.CloneOptions{
-		Progress: os.Stdout,
-		URL:      repoUrl,
-		Auth:     auth,
-	})
+	cloneOptions := &git.CloneOptions{
+		Progress:   p.Writer(),
+		URL:        repoUrl,
+		Auth:       auth,
+		NoCheckout: options.noCheckout(),
+	}
+
+	if options.depth > 0 || options.singleBranch {
+		name, err := cloneReference(repoUrl, auth, ref)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if name != "" {
+			cloneOptions.ReferenceName = name
+			cloneOptions.Depth = options.depth
+			cloneOptions.SingleBranch = options.singleBranch
+		}
+	}
+
+	p.Phase(PHASE_FETCHING)
+	r, err := git.PlainClone(repoDir, false, cloneOptions)
 
 	if err != nil {
 		return nil, err
@@ -96,7 +361,45 @@ func cloneRepo(repoId string, repoUrl string, repoDir string) (*git.Repository,
 	return r, nil
 }
 
-func pullRepo(repoId string, repoDir string) (*git.Repository, error) {
+func fetchRepo(secrets SecretStore, repoId string, repoUrl string, repoDir string, options cloneOptions, p *progress) (*git.Repository, error) {
+	log.Infof("fetching %s", repoDir)
+	r, err := git.PlainOpen(repoDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	auth, authErr := getAuth(secrets, repoId, repoUrl)
+
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	refSpecs := []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"}
+
+	// Single branch checkouts fetch the refspecs recorded by the clone.
+	if options.singleBranch {
+		refSpecs = nil
+	}
+
+	p.Phase(PHASE_FETCHING)
+	err = r.Fetch(&git.FetchOptions{
+		Progress:   p.Writer(),
+		RemoteName: "origin",
+		RefSpecs:   refSpecs,
+		Depth:      options.depth,
+		Auth:       auth,
+		Force:      true,
+	})
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func pullRepo(secrets SecretStore, repoId string, repoUrl string, repoDir string, options cloneOptions, p *progress) (*git.Repository, error) {
 	log.Infof("pulling %s", repoDir)
 	r, err := git.PlainOpen(repoDir)
 
@@ -110,24 +413,68 @@ func pullRepo(repoId string, repoDir string) (*git.Repository, error) {
 		return nil, err
 	}
 
-	dirPath := os.Getenv(SSH_ROOT) + "/" + repoId
-	auth, keyErr := getPublicKey(dirPath)
+	auth, authErr := getAuth(secrets, repoId, repoUrl)
 
-	if keyErr != nil {
-		return nil, keyErr
+	if authErr != nil {
+		return nil, authErr
 	}
 
-	err = w.Pull(&git.PullOptions{
-		Progress:   os.Stdout,
+	// Fetching and checking out separately, rather than with Worktree.Pull,
+	// lets the checkout be reported as a phase of its own.
+	p.Phase(PHASE_FETCHING)
+	err = r.Fetch(&git.FetchOptions{
+		Progress:   p.Writer(),
 		RemoteName: "origin",
+		Depth:      options.depth,
 		Auth:       auth,
 	})
 
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, err
+	}
+
+	head, err := r.Head()
+
 	if err != nil {
-		if err == git.NoErrAlreadyUpToDate {
-			return r, nil
-		}
+		return nil, err
+	}
+
+	remoteRef, err := r.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
 
+	if err != nil {
+		return nil, err
+	}
+
+	if remoteRef.Hash() == head.Hash() {
+		return r, nil
+	}
+
+	headCommit, err := r.CommitObject(head.Hash())
+
+	if err != nil {
+		return nil, err
+	}
+
+	remoteCommit, err := r.CommitObject(remoteRef.Hash())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ff, err := headCommit.IsAncestor(remoteCommit); err != nil || !ff {
+		return nil, git.ErrNonFastForwardUpdate
+	}
+
+	p.Phase(PHASE_CHECKING_OUT)
+	err = r.Storer.SetReference(plumbing.NewHashReference(head.Name(), remoteRef.Hash()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.Reset(&git.ResetOptions{Mode: git.MergeReset, Commit: remoteRef.Hash()})
+
+	if err != nil {
 		return nil, err
 	}
 
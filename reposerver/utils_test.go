@@ -0,0 +1,163 @@
+package reposerver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckoutDir(t *testing.T) {
+	tests := map[string]string{
+		"":             "/repos/1",
+		"main":         "/repos/1@main",
+		"v1.2.0":       "/repos/1@v1.2.0",
+		"feature/auth": "/repos/1@feature_auth",
+		"../../etc":    "/repos/1@.._.._etc",
+	}
+
+	for ref, want := range tests {
+		if got := checkoutDir("/repos", "1", ref); got != want {
+			t.Errorf("%q: got %s, want %s", ref, got, want)
+		}
+	}
+}
+
+func headHash(t *testing.T, dir string) string {
+	t.Helper()
+	return headCommit(t, dir).Hash.String()
+}
+
+func TestSyncPinnedRefs(t *testing.T) {
+	serverUrl, work := gitHttpServer(t)
+	repoUrl := serverUrl + "/public.git"
+	commitFile(t, work, "data.json", `{"size": 1}`)
+	runGit(t, work, "tag", "v1")
+	runGit(t, work, "push", "-q", filepath.Join(filepath.Dir(work), "public.git"), "v1")
+	tagged := headHash(t, work)
+	runGit(t, work, "checkout", "-q", "-b", "develop")
+	commitFile(t, work, "develop.json", `{}`)
+	runGit(t, work, "push", "-q", filepath.Join(filepath.Dir(work), "public.git"), "develop")
+	developed := headHash(t, work)
+	runGit(t, work, "checkout", "-q", "main")
+
+	root := t.TempDir()
+	secrets := memorySecretStore{}
+	refs := map[string]string{"": tagged, "main": tagged, "v1": tagged, "develop": developed, tagged[:7]: tagged}
+
+	for ref, want := range refs {
+		r, err := doSync(secrets, "1", repoUrl, checkoutDir(root, "1", ref), ref, cloneOptions{}, nil)
+
+		if err != nil {
+			t.Fatalf("%q: %s", ref, err)
+		}
+
+		head, err := r.Head()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if head.Hash().String() != want {
+			t.Errorf("%q: checked out %s, want %s", ref, head.Hash(), want)
+		}
+	}
+
+	// Branches follow pushes, tags and commits stay where they are.
+	commitFile(t, work, "data.json", `{"size": 2}`)
+	latest := headHash(t, work)
+	refs = map[string]string{"": latest, "main": latest, "v1": tagged, "develop": developed, tagged[:7]: tagged}
+
+	for ref, want := range refs {
+		_, err := doSync(secrets, "1", repoUrl, checkoutDir(root, "1", ref), ref, cloneOptions{}, nil)
+
+		if err != nil {
+			t.Fatalf("%q: %s", ref, err)
+		}
+
+		if got := headHash(t, checkoutDir(root, "1", ref)); got != want {
+			t.Errorf("%q: checked out %s after a push, want %s", ref, got, want)
+		}
+	}
+
+	_, err := doSync(secrets, "1", repoUrl, checkoutDir(root, "1", "missing"), "missing", cloneOptions{}, nil)
+
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v for a missing ref", err)
+	}
+
+	if _, err := os.Stat(checkoutDir(root, "1", "missing")); !os.IsNotExist(err) {
+		t.Error("the checkout of a missing ref was kept")
+	}
+}
+
+func TestResolveRefPrefersOrigin(t *testing.T) {
+	serverUrl, work := gitHttpServer(t)
+	repoDir := filepath.Join(t.TempDir(), "1@main")
+	_, err := doSync(memorySecretStore{}, "1", serverUrl+"/public.git", repoDir, "main", cloneOptions{}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitFile(t, work, "data.json", `{}`)
+	r, err := fetchRepo(memorySecretStore{}, "1", serverUrl+"/public.git", repoDir, cloneOptions{}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The local branch created by the clone is not updated by fetches.
+	hash, err := resolveRef(r, "main")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash.String() != headHash(t, work) {
+		t.Errorf("resolved main to %s rather than origin/main", hash)
+	}
+}
+
+func TestPullReportsPhases(t *testing.T) {
+	serverUrl, work := gitHttpServer(t)
+	repoDir := filepath.Join(t.TempDir(), "1")
+	secrets := memorySecretStore{}
+	_, err := doSync(secrets, "1", serverUrl+"/public.git", repoDir, "", cloneOptions{}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(filepath.Join(work, "data.json"), []byte(`{}`), 0644)
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "data")
+	runGit(t, work, "push", "-q", filepath.Join(filepath.Dir(work), "public.git"), "main")
+
+	hub := newProgressHub()
+	var phases []string
+	unsubscribe := hub.subscribe("sync", func(message *SyncProgress) {
+		if message.Message == "" {
+			phases = append(phases, message.Phase)
+		}
+	})
+	defer unsubscribe()
+
+	_, err = doSync(secrets, "1", serverUrl+"/public.git", repoDir, "", cloneOptions{}, hub.progress("sync"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{PHASE_RESOLVING, PHASE_FETCHING, PHASE_CHECKING_OUT}
+
+	if len(phases) != len(expected) || phases[0] != expected[0] || phases[1] != expected[1] || phases[2] != expected[2] {
+		t.Fatalf("got phases %v, want %v", phases, expected)
+	}
+
+	if _, err := os.Stat(filepath.Join(repoDir, "data.json")); err != nil {
+		t.Fatal("the pulled file was not checked out")
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/infor-design/selfservice/pkg/db"
+	"github.com/infor-design/selfservice/pkg/job"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+)
+
+// impersonation returns the user a job is created as when impersonation is
+// enabled. The user and groups are read from the headers set by the
+// authenticating proxy in front of the server, so the server must not be
+// reachable without it in this mode.
+func (s *Server) impersonation(r *http.Request) (*rest.ImpersonationConfig, error) {
+	if !s.Impersonate {
+		return nil, nil
+	}
+
+	return client.ImpersonationFromHeader(r.Header, s.UserHeader, s.GroupsHeader)
+}
+
+func runErrorStatus(err error) int {
+	var forbidden *client.ForbiddenError
+
+	if errors.As(err, &forbidden) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusInternalServerError
+}
+
+// discardJob deletes the row of a job whose object was not created, such as
+// when the impersonated user is not allowed to create it.
+func discardJob(jobService *job.JobService, newJob db.Job) {
+	if err := jobService.Delete(newJob); err != nil {
+		log.Errorf("job %d: %s", newJob.ID, err)
+	}
+}
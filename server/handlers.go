@@ -12,17 +12,23 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/infor-design/selfservice/pkg/application"
 	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/infor-design/selfservice/pkg/cluster"
+	"github.com/infor-design/selfservice/pkg/db"
 	"github.com/infor-design/selfservice/pkg/job"
 	repoPkg "github.com/infor-design/selfservice/pkg/repo"
+	"github.com/infor-design/selfservice/pkg/webhook"
 	"github.com/infor-design/selfservice/reposerver"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func reposHandler(service *repoPkg.Service) http.HandlerFunc {
@@ -59,18 +65,62 @@ func reposHandler(service *repoPkg.Service) http.HandlerFunc {
 
 			var newRepo repoPkg.Repo
 			newRepo.Url = newRepoPayload.Url
+			newRepo.Ref = newRepoPayload.Ref
+			newRepo.SyncInterval = newRepoPayload.Sync_Interval
+			newRepo.CloneDepth = newRepoPayload.Clone_Depth
+			newRepo.SingleBranch = newRepoPayload.Single_Branch
+			newRepo.Sparse = newRepoPayload.Sparse
+			newRepo.Submodules = newRepoPayload.Submodules
+			newRepo.Lfs = newRepoPayload.Lfs
+			newRepo.RequireSignedCommits = newRepoPayload.Require_Signed_Commits
+			newRepo.RequirePromotion = newRepoPayload.Require_Promotion
+
+			if newRepo.SyncInterval < 0 {
+				JSONError(rw, errorResp{Message: "sync_interval must not be negative"}, http.StatusBadRequest)
+				return
+			}
+
+			if newRepo.CloneDepth < 0 {
+				JSONError(rw, errorResp{Message: "clone_depth must not be negative"}, http.StatusBadRequest)
+				return
+			}
+
+			if newRepo.Sparse && newRepo.Submodules {
+				JSONError(rw, errorResp{Message: "submodules are not supported for sparse checkouts"}, http.StatusBadRequest)
+				return
+			}
+
+			newRepo.AuthMethod = authMethod(newRepoPayload.Auth_Method, newRepoPayload.Ssh_Private_Key)
 			repo := service.Create(newRepo)
 
-			if len(newRepoPayload.Ssh_Private_Key) > 0 {
-				message := reposerver.SaveSshKeyRequest{SshKey: newRepoPayload.Ssh_Private_Key, RepoId: strconv.FormatInt(int64(repo.ID), 10)}
-				_, err = rp.SaveSshKey(context.Background(), &message)
+			if len(newRepo.AuthMethod) > 0 {
+				message := reposerver.SaveCredentialsRequest{
+					RepoId:        strconv.FormatInt(int64(repo.ID), 10),
+					Method:        newRepo.AuthMethod,
+					Username:      newRepoPayload.Username,
+					Password:      newRepoPayload.Password,
+					SshKey:        newRepoPayload.Ssh_Private_Key,
+					SshPassphrase: newRepoPayload.Ssh_Passphrase,
+					Helper:        newRepoPayload.Credential_Helper,
+				}
+				_, err = rp.SaveCredentials(context.Background(), &message)
 			}
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				service.Delete(repo)
+				JSONError(rw, errorResp{Message: err.Error()}, grpcErrorStatus(err))
 				return
 			}
 
+			if len(newRepoPayload.Webhook_Secret) > 0 {
+				repo, err = service.SetWebhookSecret(repo, newRepoPayload.Webhook_Secret)
+
+				if err != nil {
+					JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+					return
+				}
+			}
+
 			newRepoBytes, err := json.Marshal(repo)
 
 			if err != nil {
@@ -85,7 +135,7 @@ func reposHandler(service *repoPkg.Service) http.HandlerFunc {
 	}
 }
 
-func repoHandler(repoService *repoPkg.Service) http.HandlerFunc {
+func repoHandler(repoService *repoPkg.Service, applicationService *application.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
 
@@ -133,17 +183,12 @@ func repoHandler(repoService *repoPkg.Service) http.HandlerFunc {
 			action := vars["action"]
 
 			if action == "sync" {
-				message := reposerver.SyncRequest{Repo: repo.Url, RepoId: strconv.FormatInt(int64(repo.ID), 10)}
-				resp, err := rp.Sync(context.Background(), &message)
+				repo, err = syncRepo(rp, repoService, applicationService, repo)
 
 				if err != nil {
-					JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+					JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
 					return
 				}
-
-				repo.Commit = resp.Commit
-				repo.Hash = resp.Hash
-				repoService.Update(repo)
 			}
 
 			repoBytes, err := json.Marshal(repo)
@@ -172,26 +217,98 @@ func repoHandler(repoService *repoPkg.Service) http.HandlerFunc {
 			}
 
 			repo.Url = updateRepoPayload.Url
-			repoService.Update(repo)
+			repo.Ref = updateRepoPayload.Ref
+			repo.SyncInterval = updateRepoPayload.Sync_Interval
+			repo.CloneDepth = updateRepoPayload.Clone_Depth
+			repo.SingleBranch = updateRepoPayload.Single_Branch
+			repo.Sparse = updateRepoPayload.Sparse
+			repo.Submodules = updateRepoPayload.Submodules
+			repo.Lfs = updateRepoPayload.Lfs
+			repo.RequireSignedCommits = updateRepoPayload.Require_Signed_Commits
+			repo.RequirePromotion = updateRepoPayload.Require_Promotion
+
+			if repo.SyncInterval < 0 {
+				JSONError(rw, errorResp{Message: "sync_interval must not be negative"}, http.StatusBadRequest)
+				return
+			}
+
+			if repo.CloneDepth < 0 {
+				JSONError(rw, errorResp{Message: "clone_depth must not be negative"}, http.StatusBadRequest)
+				return
+			}
+
+			if repo.Sparse && repo.Submodules {
+				JSONError(rw, errorResp{Message: "submodules are not supported for sparse checkouts"}, http.StatusBadRequest)
+				return
+			}
+
+			for _, app := range applicationService.ListByRepo(repo.ID) {
+				err = repoPkg.CheckPinnedRef(repo, app.Ref)
+
+				if err != nil {
+					JSONError(rw, errorResp{Message: fmt.Sprintf("application %s: %s", app.Name, err)}, http.StatusBadRequest)
+					return
+				}
+			}
 
-			if len(updateRepoPayload.Ssh_Private_Key) > 0 {
-				message := reposerver.SaveSshKeyRequest{SshKey: updateRepoPayload.Ssh_Private_Key, RepoId: strconv.FormatInt(int64(repo.ID), 10)}
-				_, err = rp.SaveSshKey(context.Background(), &message)
+			method := authMethod(updateRepoPayload.Auth_Method, updateRepoPayload.Ssh_Private_Key)
+
+			if len(method) > 0 {
+				message := reposerver.SaveCredentialsRequest{
+					RepoId:        strconv.FormatInt(int64(repo.ID), 10),
+					Method:        method,
+					Username:      updateRepoPayload.Username,
+					Password:      updateRepoPayload.Password,
+					SshKey:        updateRepoPayload.Ssh_Private_Key,
+					SshPassphrase: updateRepoPayload.Ssh_Passphrase,
+					Helper:        updateRepoPayload.Credential_Helper,
+				}
+				_, err = rp.SaveCredentials(context.Background(), &message)
+				repo.AuthMethod = method
 			}
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, grpcErrorStatus(err))
 				return
 			}
 
+			if len(updateRepoPayload.Webhook_Secret) > 0 {
+				repo, err = repoService.SetWebhookSecret(repo, updateRepoPayload.Webhook_Secret)
+
+				if err != nil {
+					JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+					return
+				}
+			}
+
+			repoService.Update(repo)
+
 			repoBytes, err := json.Marshal(repoPkg.Repo{
-				Id:         int(repo.ID),
-				Url:        repo.Url,
-				Commit:     repo.Commit,
-				Hash:       repo.Hash,
-				Created_At: repo.CreatedAt.String(),
-				Updated_At: repo.UpdatedAt.String(),
-				Deleted_At: repo.DeletedAt.Time.String(),
+				Id:                   int(repo.ID),
+				Url:                  repo.Url,
+				Commit:               repo.Commit,
+				Hash:                 repo.Hash,
+				Ref:                  repo.Ref,
+				AuthMethod:           repo.AuthMethod,
+				SyncInterval:         repo.SyncInterval,
+				CloneDepth:           repo.CloneDepth,
+				SingleBranch:         repo.SingleBranch,
+				Sparse:               repo.Sparse,
+				Submodules:           repo.Submodules,
+				Lfs:                  repo.Lfs,
+				RequireSignedCommits: repo.RequireSignedCommits,
+				Signer:               repo.Signer,
+				RequirePromotion:     repo.RequirePromotion,
+				PendingHash:          repo.PendingHash,
+				PendingCommit:        repo.PendingCommit,
+				PendingSigner:        repo.PendingSigner,
+				LastSyncAt:           repo.LastSyncAt,
+				LastSuccessAt:        repo.LastSuccessAt,
+				LastError:            repo.LastError,
+				Failures:             repo.Failures,
+				Created_At:           repo.CreatedAt.String(),
+				Updated_At:           repo.UpdatedAt.String(),
+				Deleted_At:           repo.DeletedAt.Time.String(),
 			})
 
 			if err != nil {
@@ -216,8 +333,8 @@ func repoHandler(repoService *repoPkg.Service) http.HandlerFunc {
 				return
 			}
 
-			message := reposerver.RemoveSshKeyRequest{RepoId: strconv.FormatInt(int64(repo.ID), 10)}
-			_, err = rp.RemoveSshKey(context.Background(), &message)
+			message := reposerver.RemoveCredentialsRequest{RepoId: strconv.FormatInt(int64(repo.ID), 10)}
+			_, err = rp.RemoveCredentials(context.Background(), &message)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
@@ -231,188 +348,355 @@ func repoHandler(repoService *repoPkg.Service) http.HandlerFunc {
 	}
 }
 
-func applicationHandler(applicationService *application.Service, repoService *repoPkg.Service) http.HandlerFunc {
+// repoSyncEventsHandler syncs a repo, streaming its progress as server-sent
+// events: progress events while it syncs, then a done event with the repo or
+// an error event. The sync completes even when the client goes away.
+func repoSyncEventsHandler(repoService *repoPkg.Service, applicationService *application.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+			return
+		}
+
 		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
 
 		if err != nil {
-			log.Errorln(err)
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
 		}
 
 		defer conn.Close()
 
 		rp := reposerver.NewRepoServiceClient(conn)
+		idAsUInt, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
 
-		vars := mux.Vars(r)
-		applicationID := vars["id"]
-		idAsUInt, err := strconv.ParseUint(applicationID, 10, 32)
-		resp := AppManifestHttpResp{}
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		repo, err := repoService.Get(uint(idAsUInt))
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+
+		stream, err := rp.SyncStream(context.Background(), syncRequest(repo, repo.Ref, applicationService))
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+			return
+		}
+
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Header().Set("Cache-Control", "no-cache")
+		rw.WriteHeader(http.StatusOK)
+
+		var resp *reposerver.SyncResponse
+		var syncErr error
+
+		for resp == nil && syncErr == nil {
+			progress, err := stream.Recv()
+
+			switch {
+			case err == io.EOF:
+				syncErr = errors.New("sync ended without a result")
+			case err != nil:
+				syncErr = err
+			case progress.Result != nil:
+				resp = progress.Result
+			default:
+				writeEvent(rw, "progress", SyncProgressHttpResponse{Phase: progress.Phase, Message: progress.Message})
+			}
+		}
+
+		repo, err = recordSync(rp, repoService, applicationService, repo, resp, syncErr)
+
+		if err != nil {
+			writeEvent(rw, "error", errorResp{Message: status.Convert(err).Message()})
+			return
+		}
+
+		writeEvent(rw, "done", repo)
+	}
+}
+
+func knownHostsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
 
 		if err != nil {
 			log.Errorln(err)
 		}
 
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		action := mux.Vars(r)["action"]
+
 		switch r.Method {
 		case "GET":
-			app, err := applicationService.Get(uint(idAsUInt))
+			resp, err := rp.ListHostKeys(context.Background(), &reposerver.ListHostKeysRequest{})
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, grpcErrorStatus(err))
 				return
 			}
 
-			repo, err := repoService.Get(app.RepoID)
+			hostKeys := resp.HostKeys
 
-			if err != nil {
-				log.Errorln(err)
+			if hostKeys == nil {
+				hostKeys = []*reposerver.HostKey{}
 			}
 
-			if err == nil {
-				repoDirRequest := reposerver.RepoDirRequest{RepoUrl: repo.Url}
-				repoDirResponse, err := rp.GetRepoDir(context.Background(), &repoDirRequest)
-
-				if err != nil {
-					log.Errorln(err)
-				}
-
-				fullManifestPath := path.Join(repoDirResponse.Path, app.ManifestPath)
-				message := reposerver.ManifestsRequest{Path: fullManifestPath}
-				response, err := rp.GetManifests(context.Background(), &message)
-
-				if err != nil {
-					log.Errorln(err)
-				}
+			respBytes, err := json.Marshal(hostKeys)
 
-				resp.Manifests = response
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
 			}
 
-			resp.App = app
-			respBytes, err := json.Marshal(resp)
+			io.WriteString(rw, string(respBytes))
+		case "POST", "DELETE":
+			var payload HostKeyPayload
+			err := decodeJSONBody(rw, r, &payload)
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
 				return
 			}
 
-			io.WriteString(rw, string(respBytes))
-			return
-		case "PUT":
-			app, err := applicationService.Get(uint(idAsUInt))
+			message := reposerver.HostKeyRequest{Host: payload.Host, Fingerprint: payload.Fingerprint}
+
+			switch {
+			case r.Method == "DELETE":
+				_, err = rp.RemoveHostKey(context.Background(), &message)
+			case action == "approve":
+				_, err = rp.ApproveHostKey(context.Background(), &message)
+			default:
+				_, err = rp.AddHostKey(context.Background(), &reposerver.AddHostKeyRequest{Line: payload.Line})
+			}
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
 				return
 			}
 
-			var updateAppPayload application.ApplicationUpdate
-			err = decodeJSONBody(rw, r, &updateAppPayload)
+			http.Error(rw, "", http.StatusNoContent)
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+func trustedKeysHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+
+		switch r.Method {
+		case "GET":
+			resp, err := rp.ListTrustedKeys(context.Background(), &reposerver.ListTrustedKeysRequest{})
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, grpcErrorStatus(err))
 				return
 			}
 
-			app.ManifestPath = updateAppPayload.ManifestPath
-			app.RepoID = updateAppPayload.RepoID
-			app.Name = updateAppPayload.Name
-			applicationService.Update(app)
+			trustedKeys := resp.TrustedKeys
 
-			repo, err := repoService.Get(app.RepoID)
+			if trustedKeys == nil {
+				trustedKeys = []*reposerver.TrustedKey{}
+			}
+
+			respBytes, err := json.Marshal(trustedKeys)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			repoDirRequest := reposerver.RepoDirRequest{RepoUrl: repo.Url}
-			repoDirResponse, err := rp.GetRepoDir(context.Background(), &repoDirRequest)
+			io.WriteString(rw, string(respBytes))
+		case "POST":
+			var payload TrustedKeyPayload
+			err := decodeJSONBody(rw, r, &payload)
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
 				return
 			}
 
-			fullManifestPath := path.Join(repoDirResponse.Path, app.ManifestPath)
-			message := reposerver.ManifestsRequest{Path: fullManifestPath}
-			response, err := rp.GetManifests(context.Background(), &message)
+			resp, err := rp.AddTrustedKey(context.Background(), &reposerver.AddTrustedKeyRequest{Key: payload.Key})
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
 				return
 			}
 
-			resp.App = app
-			resp.Manifests = response
-			respBytes, err := json.Marshal(resp)
+			respBytes, err := json.Marshal(resp.TrustedKey)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
+			rw.WriteHeader(http.StatusCreated)
 			io.WriteString(rw, string(respBytes))
-			return
 		case "DELETE":
-			app, err := applicationService.Get(uint(idAsUInt))
+			var payload TrustedKeyPayload
+			err := decodeJSONBody(rw, r, &payload)
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
 				return
 			}
 
-			err = applicationService.Delete(app)
+			_, err = rp.RemoveTrustedKey(context.Background(), &reposerver.RemoveTrustedKeyRequest{Fingerprint: payload.Fingerprint})
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
 				return
 			}
 
 			http.Error(rw, "", http.StatusNoContent)
-			io.WriteString(rw, "")
 		default:
 			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
 		}
 	}
 }
 
-func applicationsHandler(service *application.Service) http.HandlerFunc {
+// repoPromotionsHandler lists the commits promoted for a repo, and promotes
+// its pending commit or rolls back to a commit promoted before.
+func repoPromotionsHandler(repoService *repoPkg.Service, applicationService *application.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		repo, err := repoService.Get(uint(idAsUInt))
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+
 		switch r.Method {
 		case "GET":
-			apps := service.List()
-			appsBytes, err := json.Marshal(apps)
+			promotions, err := repoService.Promotions(repo)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			io.WriteString(rw, string(appsBytes))
+			respBytes, err := json.Marshal(promotions)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
 		case "POST":
-			var newAppPayload application.Application
-			err := decodeJSONBody(rw, r, &newAppPayload)
+			var payload PromotionPayload
+			err := decodeJSONBody(rw, r, &payload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			if !repo.RequirePromotion {
+				JSONError(rw, errorResp{Message: "the repo does not require promotion"}, http.StatusBadRequest)
+				return
+			}
+
+			if payload.Hash == "" {
+				payload.Hash = repo.PendingHash
+			}
+
+			if payload.Hash == "" {
+				JSONError(rw, errorResp{Message: "no commit is pending"}, http.StatusBadRequest)
+				return
+			}
+
+			promotable, err := repoService.Promotable(repo, payload.Hash)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			newApp := service.Create(newAppPayload)
-			newAppBytes, err := json.Marshal(newApp)
+			if !promotable {
+				JSONError(rw, errorResp{Message: fmt.Sprintf("commit %s is neither pending nor promoted before", payload.Hash)}, http.StatusBadRequest)
+				return
+			}
+
+			message := reposerver.CommitRequest{
+				Repo:             repo.Url,
+				RepoId:           strconv.FormatInt(int64(repo.ID), 10),
+				Ref:              repo.Ref,
+				Hash:             payload.Hash,
+				RequireSignature: repo.RequireSignedCommits,
+				Options:          repoOptions(repo),
+			}
+			resp, err := rp.DescribeCommit(context.Background(), &message)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+				return
+			}
+
+			repo, err = repoService.Promote(repo, resp.Hash, resp.Commit, resp.Signer)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			io.WriteString(rw, string(newAppBytes))
+			err = applicationService.Reconcile(repo.ID, descriptors(repo, resp.Applications))
+
+			if err != nil {
+				log.Errorf("repo %d: %s", repo.ID, err)
+			}
+
+			updateSparseCheckouts(rp, repo, applicationService)
+			respBytes, err := json.Marshal(repo)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
 		default:
 			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
 		}
 	}
 }
 
-func (s *Server) settingsHandler() http.HandlerFunc {
+func applicationHandler(applicationService *application.Service, repoService *repoPkg.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
 
@@ -424,21 +708,42 @@ func (s *Server) settingsHandler() http.HandlerFunc {
 
 		rp := reposerver.NewRepoServiceClient(conn)
 
+		vars := mux.Vars(r)
+		applicationID := vars["id"]
+		idAsUInt, err := strconv.ParseUint(applicationID, 10, 32)
+		resp := AppManifestHttpResp{}
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
 		switch r.Method {
 		case "GET":
-			message := reposerver.PathsRequest{}
-			paths, err := rp.GetPaths(context.Background(), &message)
+			app, err := applicationService.Get(uint(idAsUInt))
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			resp := SettingsHttpResponse{
-				RepoRoot:   paths.RepoRoot,
-				SshRoot:    paths.SshRoot,
-				PrivateKey: paths.PrivateKey,
+			repo, err := repoService.Get(app.RepoID)
+
+			if err != nil {
+				log.Errorln(err)
+			}
+
+			if err == nil {
+				response, err := rp.GetManifests(context.Background(), manifestsRequest(repo, app))
+
+				if err != nil {
+					log.Errorln(err)
+				}
+
+				addClusterInput(response, app.Clusters)
+				resp.Manifests = response
 			}
+
+			resp.App = app
 			respBytes, err := json.Marshal(resp)
 
 			if err != nil {
@@ -447,124 +752,1046 @@ func (s *Server) settingsHandler() http.HandlerFunc {
 			}
 
 			io.WriteString(rw, string(respBytes))
-		default:
+			return
+		case "PUT":
+			app, err := applicationService.Get(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			var updateAppPayload application.ApplicationUpdate
+			err = decodeJSONBody(rw, r, &updateAppPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			_, err = client.ParseStatusCheck(updateAppPayload.StatusCheck)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			manifestPath, err := reposerver.RepoPath(updateAppPayload.ManifestPath)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, http.StatusBadRequest)
+				return
+			}
+
+			app.ManifestPath = manifestPath
+			app.RepoID = updateAppPayload.RepoID
+			app.Name = updateAppPayload.Name
+			app.Ref = updateAppPayload.Ref
+			app.StatusCheck = updateAppPayload.StatusCheck
+			repo, err := repoService.Get(app.RepoID)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			err = repoPkg.CheckPinnedRef(repo, app.Ref)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			applicationService.Update(app)
+			err = applicationService.SetClusters(app, updateAppPayload.Clusters)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			app, err = applicationService.Get(app.ID)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			updateSparseCheckouts(rp, repo, applicationService)
+			response, err := rp.GetManifests(context.Background(), manifestsRequest(repo, app))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+				return
+			}
+
+			addClusterInput(response, app.Clusters)
+			resp.App = app
+			resp.Manifests = response
+			respBytes, err := json.Marshal(resp)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
+			return
+		case "DELETE":
+			app, err := applicationService.Get(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			err = applicationService.Delete(app)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			http.Error(rw, "", http.StatusNoContent)
+			io.WriteString(rw, "")
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+func applicationsHandler(service *application.Service, repoService *repoPkg.Service) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			apps := service.List()
+			appsBytes, err := json.Marshal(apps)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(appsBytes))
+		case "POST":
+			var newAppPayload application.Application
+			err := decodeJSONBody(rw, r, &newAppPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			_, err = client.ParseStatusCheck(newAppPayload.StatusCheck)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			newAppPayload.ManifestPath, err = reposerver.RepoPath(newAppPayload.ManifestPath)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, http.StatusBadRequest)
+				return
+			}
+
+			repo, err := repoService.Get(newAppPayload.RepoID)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			err = repoPkg.CheckPinnedRef(repo, newAppPayload.Ref)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			newApp, err := service.Create(newAppPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			if repo.Sparse {
+				conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+				if err != nil {
+					log.Errorln(err)
+				} else {
+					defer conn.Close()
+					updateSparseCheckouts(reposerver.NewRepoServiceClient(conn), repo, service)
+				}
+			}
+
+			newAppBytes, err := json.Marshal(newApp)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(newAppBytes))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+func clustersHandler(service *cluster.Service, clusters *client.Clusters) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			clustersBytes, err := json.Marshal(service.List())
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(clustersBytes))
+		case "POST":
+			var newClusterPayload cluster.ClusterCreate
+			err := decodeJSONBody(rw, r, &newClusterPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			newCluster, err := service.Create(newClusterPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			_, err = clusters.Get(newCluster.ID)
+
+			if err != nil {
+				log.Errorf("cluster %s: %v", newCluster.Name, err)
+			}
+
+			newClusterBytes, err := json.Marshal(cluster.AsCluster(newCluster))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(newClusterBytes))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+func clusterHandler(service *cluster.Service, clusters *client.Clusters) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		clusterId := vars["id"]
+		idAsUInt, err := strconv.ParseUint(clusterId, 10, 32)
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		existing, err := service.Get(uint(idAsUInt))
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			clusterBytes, err := json.Marshal(cluster.AsCluster(existing))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(clusterBytes))
+		case "PUT":
+			var updateClusterPayload cluster.ClusterUpdate
+			err = decodeJSONBody(rw, r, &updateClusterPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			updated, err := service.Update(existing, updateClusterPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			clusters.Remove(updated.ID)
+			_, err = clusters.Get(updated.ID)
+
+			if err != nil {
+				log.Errorf("cluster %s: %v", updated.Name, err)
+			}
+
+			clusterBytes, err := json.Marshal(cluster.AsCluster(updated))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(clusterBytes))
+		case "DELETE":
+			err = service.Delete(existing)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			clusters.Remove(existing.ID)
+			http.Error(rw, "", http.StatusNoContent)
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+// clusterFor resolves the cluster a job of the application runs on, see
+// cluster.Bound.
+func (s *Server) clusterFor(app db.Application, clusterId uint) (uint, *client.Client, error) {
+	clusterId, err := cluster.Bound(app, clusterId)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	kube, err := s.clusters.Get(clusterId)
+	return clusterId, kube, err
+}
+
+func (s *Server) settingsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+
+		switch r.Method {
+		case "GET":
+			message := reposerver.SettingsRequest{}
+			settings, err := rp.GetSettings(context.Background(), &message)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			resp := SettingsHttpResponse{
+				SecretStore: settings.SecretStore,
+				Keys:        []KeyFingerprint{},
+			}
+
+			for _, key := range settings.Keys {
+				resp.Keys = append(resp.Keys, KeyFingerprint{
+					RepoId:      key.RepoId,
+					KeyType:     key.KeyType,
+					Fingerprint: key.Fingerprint,
+				})
+			}
+
+			respBytes, err := json.Marshal(resp)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+type RetainedLogs struct {
+	Logs []string `json:"logs"`
+}
+
+type FileData struct {
+	DateCreated time.Time `json:"date_created"`
+	Path        string    `json:"path"`
+	Logs        []string  `json:"logs"`
+}
+
+type Pair struct {
+	Key   string   `json:"file"`
+	Value FileData `json:"file_data"`
+}
+
+type PairList []Pair
+
+func (p PairList) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p PairList) Len() int           { return len(p) }
+func (p PairList) Less(i, j int) bool { return p[i].Value.DateCreated.Before(p[j].Value.DateCreated) }
+
+func (s *Server) logsHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			vars := mux.Vars(r)
+			jobId := vars["id"]
+			libRegEx, err := regexp.Compile(`^.+.(log)$`)
+
+			if err != nil {
+				log.Errorln(err)
+			}
+
+			logFiles := make(map[string]FileData)
+
+			err = filepath.Walk(path.Join(s.logsPath, jobId), func(path string, info os.FileInfo, err error) error {
+				if err == nil {
+					fileName := info.Name()
+					if libRegEx.MatchString(fileName) {
+						_, _, ctime, err := statTimes(path)
+
+						if err != nil {
+							return err
+						}
+
+						logLines, err := readLines(path)
+
+						if err != nil {
+							return err
+						}
+
+						logFiles[fileName] = FileData{
+							DateCreated: ctime,
+							Path:        path,
+							Logs:        logLines,
+						}
+					}
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				log.Errorln(err)
+			}
+
+			p := make(PairList, len(logFiles))
+			i := 0
+
+			for k, v := range logFiles {
+				p[i] = Pair{k, v}
+				i++
+			}
+
+			sort.Sort(p)
+			bytes, _ := json.Marshal(p)
+			io.WriteString(rw, string(bytes))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+func (s *Server) applicationJobHandler(applicationService *application.Service, jobService *job.JobService) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		applicationID := vars["id"]
+		idAsUInt, err := strconv.ParseUint(applicationID, 10, 32)
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		switch r.Method {
+		case "GET":
+			vars := mux.Vars(r)
+			applicationId := vars["id"]
+			idAsUInt, err := strconv.ParseUint(applicationId, 10, 32)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			jobs, err := jobService.GetAllByAppId(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			respBytes, err := json.Marshal(jobs)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
+			return
+		case "POST":
+			app, err := applicationService.Get(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			if app.ArchivedAt != nil {
+				JSONError(rw, errorResp{Message: "application is archived, its descriptor was removed from the repo"}, http.StatusConflict)
+				return
+			}
+
+			check, err := client.ParseStatusCheck(app.StatusCheck)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			if check != nil {
+				s.runResource(rw, r, app, check, jobService)
+				return
+			}
+
+			var jobPayload client.JobConfig
+			decoder := json.NewDecoder(r.Body)
+			err = decoder.Decode(&jobPayload)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			if jobPayload.ObjectMeta.Namespace == "" {
+				jobPayload.ObjectMeta.Namespace = app.Namespace
+			}
+
+			if violations := client.JobViolations(jobPayload); len(violations) > 0 {
+				JSONError(rw, errorResp{Message: strings.Join(violations, "; ")}, http.StatusBadRequest)
+				return
+			}
+
+			randomString, err := GenerateRandomString(client.NAME_SUFFIX_LENGTH)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			clusterId, kube, err := s.clusterFor(app, jobPayload.Cluster)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			impersonate, err := s.impersonation(r)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusUnauthorized)
+				return
+			}
+
+			manifestHash, err := s.manifestHash(app, jobPayload.ManifestHash)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			jobName := fmt.Sprintf("%s-%s", jobPayload.ObjectMeta.Name, randomString)
+			jobConfig := client.JobConfig{ObjectMeta: jobPayload.ObjectMeta, Spec: jobPayload.Spec, Cluster: clusterId, ManifestHash: manifestHash, Impersonate: impersonate}
+			newJob := jobService.Create(job.Job{Name: jobName, ApplicationID: uint(idAsUInt), ClusterID: clusterId, ManifestHash: manifestHash})
+			labels := make(map[string]string)
+
+			labels["invoked"] = ""
+			labels["job_id"] = strconv.FormatUint(uint64(newJob.ID), 10)
+
+			jobConfig.Labels = labels
+			resp, err := kube.Run(jobName, jobConfig)
+
+			if err != nil {
+				discardJob(jobService, newJob)
+				JSONError(rw, errorResp{Message: err.Error()}, runErrorStatus(err))
+				return
+			}
+
+			spec, _ := json.Marshal(resp.Spec)
+			meta, _ := json.Marshal(jobPayload.ObjectMeta)
+			newJob.Spec = spec
+			newJob.Meta = meta
+			jobService.Update(newJob)
+
+			respBytes, err := json.Marshal(JobRunResponse{
+				Job:    newJob,
+				Config: jobConfig,
+				Spec:   resp.Spec,
+				Status: resp.Status,
+			})
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
+		default:
 			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
 		}
 	}
 }
 
-type RetainedLogs struct {
-	Logs []string `json:"logs"`
+func (s *Server) runResource(rw http.ResponseWriter, r *http.Request, app db.Application, check *client.StatusCheck, jobService *job.JobService) {
+	var resourcePayload client.ResourceConfig
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&resourcePayload)
+
+	if err != nil {
+		JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	if resourcePayload.ObjectMeta.Namespace == "" {
+		resourcePayload.ObjectMeta.Namespace = app.Namespace
+	}
+
+	if violations := client.ResourceViolations(resourcePayload); len(violations) > 0 {
+		JSONError(rw, errorResp{Message: strings.Join(violations, "; ")}, http.StatusBadRequest)
+		return
+	}
+
+	randomString, err := GenerateRandomString(client.NAME_SUFFIX_LENGTH)
+
+	if err != nil {
+		JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	clusterId, kube, err := s.clusterFor(app, resourcePayload.Cluster)
+
+	if err != nil {
+		JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	impersonate, err := s.impersonation(r)
+
+	if err != nil {
+		JSONError(rw, errorResp{Message: err.Error()}, http.StatusUnauthorized)
+		return
+	}
+
+	manifestHash, err := s.manifestHash(app, resourcePayload.ManifestHash)
+
+	if err != nil {
+		JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	jobName := fmt.Sprintf("%s-%s", resourcePayload.ObjectMeta.Name, randomString)
+	resourcePayload.Cluster = clusterId
+	resourcePayload.Impersonate = impersonate
+	resourcePayload.ManifestHash = manifestHash
+	newJob := jobService.Create(job.Job{Name: jobName, ApplicationID: app.ID, ClusterID: clusterId, ManifestHash: resourcePayload.ManifestHash})
+	labels := make(map[string]string)
+
+	labels["invoked"] = ""
+	labels["job_id"] = strconv.FormatUint(uint64(newJob.ID), 10)
+
+	resourcePayload.Labels = labels
+	resp, err := kube.RunResource(jobName, check, resourcePayload)
+
+	if err != nil {
+		discardJob(jobService, newJob)
+		JSONError(rw, errorResp{Message: err.Error()}, runErrorStatus(err))
+		return
+	}
+
+	spec, _ := json.Marshal(resourcePayload.Spec)
+	meta, _ := json.Marshal(resourcePayload.ObjectMeta)
+	newJob.Phase = job.PhasePending
+	newJob.Spec = spec
+	newJob.Meta = meta
+	jobService.Update(newJob)
+
+	respBytes, err := json.Marshal(ResourceRunResponse{
+		Job:      newJob,
+		Config:   resourcePayload,
+		Resource: resp.Object,
+	})
+
+	if err != nil {
+		JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+		return
+	}
+
+	io.WriteString(rw, string(respBytes))
 }
 
-type FileData struct {
-	DateCreated time.Time `json:"date_created"`
-	Path        string    `json:"path"`
-	Logs        []string  `json:"logs"`
+// manifestHash returns the commit of the manifests a job was submitted from,
+// as echoed by the client, or the commit the manifests currently resolve to.
+// Echoed commits must hold the manifests of the application. Jobs of repos
+// requiring promotion always run against the promoted commit.
+func (s *Server) manifestHash(app db.Application, hash string) (string, error) {
+	repo, err := s.repoService.Get(app.RepoID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if promoted := promotedHash(repo); promoted != "" {
+		return promoted, nil
+	}
+
+	conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	if err != nil {
+		log.Errorln(err)
+		return "", nil
+	}
+
+	defer conn.Close()
+
+	rp := reposerver.NewRepoServiceClient(conn)
+	message := manifestsRequest(repo, app)
+	message.Hash = hash
+	response, err := rp.GetManifests(context.Background(), message)
+
+	if err != nil && hash != "" {
+		return "", errors.Errorf("invalid manifest_hash %s: %s", hash, status.Convert(err).Message())
+	}
+
+	if err != nil {
+		log.Errorln(err)
+		return "", nil
+	}
+
+	return response.Hash, nil
 }
 
-type Pair struct {
-	Key   string   `json:"file"`
-	Value FileData `json:"file_data"`
+// jobManifestsHandler returns the manifests at the commit a job was submitted
+// from, so the form can be shown as it was when the job ran.
+func (s *Server) jobManifestsHandler(jobService *job.JobService, applicationService *application.Service) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			job, err := jobService.Get(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
+				return
+			}
+
+			if job.ManifestHash == "" {
+				JSONError(rw, errorResp{Message: "the manifests of this job were not recorded"}, http.StatusNotFound)
+				return
+			}
+
+			app, err := applicationService.Get(job.ApplicationID)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			repo, err := s.repoService.Get(app.RepoID)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			message := manifestsRequest(repo, app)
+			message.Hash = job.ManifestHash
+			response, err := rp.GetManifests(context.Background(), message)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+				return
+			}
+
+			respBytes, err := json.Marshal(response)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+func (s *Server) jobHandler(jobService *job.JobService) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			vars := mux.Vars(r)
+			jobId := vars["id"]
+			idAsUInt, err := strconv.ParseUint(jobId, 10, 32)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			job, err := jobService.Get(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			respBytes, err := json.Marshal(job)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			io.WriteString(rw, string(respBytes))
+		case "DELETE":
+			vars := mux.Vars(r)
+			jobId := vars["id"]
+			idAsUInt, err := strconv.ParseUint(jobId, 10, 32)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			app, err := jobService.Get(uint(idAsUInt))
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			err = jobService.Delete(app)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
+			}
+
+			http.Error(rw, "", http.StatusNoContent)
+			io.WriteString(rw, "")
+			io.WriteString(rw, string(""))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
 }
 
-type PairList []Pair
-
-func (p PairList) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
-func (p PairList) Len() int           { return len(p) }
-func (p PairList) Less(i, j int) bool { return p[i].Value.DateCreated.Before(p[j].Value.DateCreated) }
-
-func (s *Server) logsHandler() http.HandlerFunc {
+// webhookHandler syncs the repos a signed push event is for. Repos are
+// matched by url, and synced when the push moved the ref they or their
+// applications follow.
+func (s *Server) webhookHandler(applicationService *application.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		switch r.Method {
-		case "GET":
-			vars := mux.Vars(r)
-			jobId := vars["id"]
-			libRegEx, err := regexp.Compile(`^.+.(log)$`)
+		case "POST":
+			provider := mux.Vars(r)["provider"]
+			body, err := io.ReadAll(http.MaxBytesReader(rw, r.Body, 1048576))
 
 			if err != nil {
-				log.Errorln(err)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
 			}
 
-			logFiles := make(map[string]FileData)
+			push, err := webhook.Parse(provider, r.Header, body)
+			resp := WebhookResponse{Synced: []uint{}}
 
-			err = filepath.Walk(path.Join(s.logsPath, jobId), func(path string, info os.FileInfo, err error) error {
-				if err == nil {
-					fileName := info.Name()
-					if libRegEx.MatchString(fileName) {
-						_, _, ctime, err := statTimes(path)
+			if err == webhook.ErrIgnoredEvent {
+				respBytes, _ := json.Marshal(resp)
+				io.WriteString(rw, string(respBytes))
+				return
+			}
 
-						if err != nil {
-							return err
-						}
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+				return
+			}
 
-						logLines, err := readLines(path)
+			verified := false
 
-						if err != nil {
-							return err
-						}
+			for _, repo := range s.repoService.List() {
+				if !push.Matches(repo.Url) {
+					continue
+				}
 
-						logFiles[fileName] = FileData{
-							DateCreated: ctime,
-							Path:        path,
-							Logs:        logLines,
-						}
-					}
+				webhookSecret, err := s.repoService.WebhookSecret(repo)
+
+				if err != nil {
+					log.Errorf("repo %d: %s", repo.ID, err)
+					continue
 				}
 
-				return nil
-			})
+				err = webhook.Verify(provider, r.Header, body, webhookSecret)
 
-			if err != nil {
-				log.Errorln(err)
+				if err != nil {
+					log.Warnf("repo %d: %s", repo.ID, err)
+					continue
+				}
+
+				verified = true
+
+				if !pushUpdates(push, repo, applicationService) {
+					continue
+				}
+
+				repoId := repo.ID
+				s.webhooks.Trigger(repoId, func() {
+					s.syncRepoById(repoId, applicationService)
+				})
+				resp.Synced = append(resp.Synced, repoId)
 			}
 
-			p := make(PairList, len(logFiles))
-			i := 0
+			// An unmatched push is refused like an unsigned one, so that
+			// the response does not reveal which repos are registered.
+			if !verified {
+				JSONError(rw, errorResp{Message: "invalid webhook signature"}, http.StatusUnauthorized)
+				return
+			}
 
-			for k, v := range logFiles {
-				p[i] = Pair{k, v}
-				i++
+			respBytes, err := json.Marshal(resp)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				return
 			}
 
-			sort.Sort(p)
-			bytes, _ := json.Marshal(p)
-			io.WriteString(rw, string(bytes))
+			rw.WriteHeader(http.StatusAccepted)
+			io.WriteString(rw, string(respBytes))
 		default:
 			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
 		}
 	}
 }
 
-func (s *Server) applicationJobHandler(applicationService *application.Service, jobService *job.JobService) http.HandlerFunc {
+func (s *Server) syncRepoById(repoId uint, applicationService *application.Service) {
+	conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	defer conn.Close()
+
+	repo, err := s.repoService.Get(repoId)
+
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	_, err = syncRepo(reposerver.NewRepoServiceClient(conn), s.repoService, applicationService, repo)
+
+	if err != nil {
+		log.Errorf("repo %d: %s", repoId, err)
+	}
+}
+
+// repoContentHandler browses the tree and files of a repo at its tracked ref,
+// or at the ref and commit given as query parameters.
+func repoContentHandler(repoService *repoPkg.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		applicationID := vars["id"]
-		idAsUInt, err := strconv.ParseUint(applicationID, 10, 32)
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
 
 		if err != nil {
 			log.Errorln(err)
 		}
 
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
 		switch r.Method {
 		case "GET":
-			vars := mux.Vars(r)
-			applicationId := vars["id"]
-			idAsUInt, err := strconv.ParseUint(applicationId, 10, 32)
+			repo, err := repoService.Get(uint(idAsUInt))
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
 				return
 			}
 
-			jobs, err := jobService.GetAllByAppId(uint(idAsUInt))
+			query := r.URL.Query()
+			message := reposerver.ContentRequest{
+				Repo:    repo.Url,
+				RepoId:  strconv.FormatInt(int64(repo.ID), 10),
+				Ref:     repo.Ref,
+				Hash:    query.Get("hash"),
+				Path:    query.Get("path"),
+				Options: repoOptions(repo),
+			}
+
+			if query.Has("ref") {
+				message.RemoteRef = query.Get("ref")
+			}
+
+			var resp interface{}
+
+			if vars["action"] == "file" {
+				var file *reposerver.FileResponse
+				file, err = rp.GetFile(context.Background(), &message)
+
+				if err == nil {
+					resp = fileResponse(file)
+				}
+			} else {
+				resp, err = rp.GetTree(context.Background(), &message)
+			}
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
 				return
 			}
 
-			respBytes, err := json.Marshal(jobs)
+			respBytes, err := json.Marshal(resp)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
@@ -572,52 +1799,77 @@ func (s *Server) applicationJobHandler(applicationService *application.Service,
 			}
 
 			io.WriteString(rw, string(respBytes))
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+// applicationDocsHandler renders the markdown docs next to the manifests of
+// an application.
+func applicationDocsHandler(applicationService *application.Service, repoService *repoPkg.Service) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 			return
-		case "POST":
-			var jobPayload client.JobConfig
-			decoder := json.NewDecoder(r.Body)
-			err := decoder.Decode(&jobPayload)
+		}
+
+		switch r.Method {
+		case "GET":
+			app, err := applicationService.Get(uint(idAsUInt))
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
 				return
 			}
 
-			randomString, err := GenerateRandomString(10)
+			repo, err := repoService.Get(app.RepoID)
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
 				return
 			}
 
-			jobName := fmt.Sprintf("%s-%s", jobPayload.ObjectMeta.Name, randomString)
-			jobConfig := client.JobConfig{ObjectMeta: jobPayload.ObjectMeta, Spec: jobPayload.Spec}
-			newJob := jobService.Create(job.Job{Name: jobName, ApplicationID: uint(idAsUInt)})
-			labels := make(map[string]string)
+			manifests := manifestsRequest(repo, app)
+			message := reposerver.ContentRequest{
+				Repo:    manifests.Repo,
+				RepoId:  manifests.RepoId,
+				Ref:     manifests.Ref,
+				Hash:    manifests.Hash,
+				Path:    manifests.Path,
+				Options: manifests.Options,
+			}
 
-			labels["invoked"] = ""
-			labels["job_id"] = strconv.FormatUint(uint64(newJob.ID), 10)
+			if r.URL.Query().Has("hash") {
+				message.Hash = r.URL.Query().Get("hash")
+			}
 
-			jobConfig.Labels = labels
-			resp, err := s.pods.Run(jobName, jobConfig)
+			response, err := rp.GetDocs(context.Background(), &message)
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
 				return
 			}
 
-			spec, _ := json.Marshal(resp.Spec)
-			meta, _ := json.Marshal(jobPayload.ObjectMeta)
-			newJob.Spec = spec
-			newJob.Meta = meta
-			jobService.Update(newJob)
+			docs := DocsHttpResponse{Hash: response.Hash, Docs: []DocHttpResponse{}}
 
-			respBytes, err := json.Marshal(JobRunResponse{
-				Job:    newJob,
-				Config: jobConfig,
-				Spec:   resp.Spec,
-				Status: resp.Status,
-			})
+			for _, doc := range response.Docs {
+				docs.Docs = append(docs.Docs, DocHttpResponse{Path: doc.Path, Html: doc.Html})
+			}
+
+			respBytes, err := json.Marshal(docs)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
@@ -631,27 +1883,71 @@ func (s *Server) applicationJobHandler(applicationService *application.Service,
 	}
 }
 
-func (s *Server) jobHandler(jobService *job.JobService) http.HandlerFunc {
+func applicationValidateHandler(applicationService *application.Service, repoService *repoPkg.Service) http.HandlerFunc {
 	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
 		switch r.Method {
 		case "GET":
-			vars := mux.Vars(r)
-			jobId := vars["id"]
-			idAsUInt, err := strconv.ParseUint(jobId, 10, 32)
+			app, err := applicationService.Get(uint(idAsUInt))
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
 				return
 			}
 
-			job, err := jobService.Get(uint(idAsUInt))
+			repo, err := repoService.Get(app.RepoID)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
+				return
+			}
+
+			check, err := client.ParseStatusCheck(app.StatusCheck)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			respBytes, err := json.Marshal(job)
+			manifests := manifestsRequest(repo, app)
+			message := reposerver.ValidateRequest{
+				Repo:     manifests.Repo,
+				RepoId:   manifests.RepoId,
+				Ref:      manifests.Ref,
+				Hash:     manifests.Hash,
+				Path:     manifests.Path,
+				Resource: check != nil,
+				Options:  manifests.Options,
+			}
+
+			if r.URL.Query().Has("hash") {
+				message.Hash = r.URL.Query().Get("hash")
+			}
+
+			response, err := rp.ValidateApplication(context.Background(), &message)
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+				return
+			}
+
+			respBytes, err := json.Marshal(response)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
@@ -659,33 +1955,107 @@ func (s *Server) jobHandler(jobService *job.JobService) http.HandlerFunc {
 			}
 
 			io.WriteString(rw, string(respBytes))
-		case "DELETE":
-			vars := mux.Vars(r)
-			jobId := vars["id"]
-			idAsUInt, err := strconv.ParseUint(jobId, 10, 32)
+		default:
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+		}
+	}
+}
+
+// applicationHistoryHandler lists the commits changing the manifests of an
+// application, and diffs them between two commits flagging breaking schema
+// changes. Diffs are from the synced commit of the repo by default.
+func applicationHistoryHandler(applicationService *application.Service, repoService *repoPkg.Service) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			app, err := applicationService.Get(uint(idAsUInt))
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
 				return
 			}
 
-			app, err := jobService.Get(uint(idAsUInt))
+			repo, err := repoService.Get(app.RepoID)
 
 			if err != nil {
-				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+				JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
 				return
 			}
 
-			err = jobService.Delete(app)
+			query := r.URL.Query()
+			manifests := manifestsRequest(repo, app)
+			var resp interface{}
+
+			if vars["action"] == "diff" {
+				message := reposerver.DiffRequest{
+					Repo:    manifests.Repo,
+					RepoId:  manifests.RepoId,
+					Ref:     manifests.Ref,
+					Path:    manifests.Path,
+					From:    query.Get("from"),
+					To:      query.Get("to"),
+					Options: manifests.Options,
+				}
+
+				if message.From == "" && manifests.Ref == repo.Ref {
+					message.From = repo.Hash
+				}
+
+				resp, err = rp.DiffApplication(context.Background(), &message)
+			} else {
+				message := reposerver.HistoryRequest{
+					Repo:    manifests.Repo,
+					RepoId:  manifests.RepoId,
+					Ref:     manifests.Ref,
+					Hash:    query.Get("hash"),
+					Path:    manifests.Path,
+					Options: manifests.Options,
+				}
+
+				if query.Has("limit") {
+					limit, err := strconv.ParseInt(query.Get("limit"), 10, 32)
+
+					if err != nil {
+						JSONError(rw, errorResp{Message: "invalid limit"}, http.StatusBadRequest)
+						return
+					}
+
+					message.Limit = int32(limit)
+				}
+
+				resp, err = rp.GetHistory(context.Background(), &message)
+			}
+
+			if err != nil {
+				JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+				return
+			}
+
+			respBytes, err := json.Marshal(resp)
 
 			if err != nil {
 				JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
 				return
 			}
 
-			http.Error(rw, "", http.StatusNoContent)
-			io.WriteString(rw, "")
-			io.WriteString(rw, string(""))
+			io.WriteString(rw, string(respBytes))
 		default:
 			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
 		}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/infor-design/selfservice/pkg/application"
+	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/infor-design/selfservice/reposerver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// optionsParams are the query parameters of an options request which are not
+// field values.
+var optionsParams = []string{"cluster", "hash"}
+
+// optionsHandler resolves the x-selfservice-options of a field of the schema
+// of an application. The values of the fields the options depend on are
+// passed as query parameters.
+func (s *Server) optionsHandler(applicationService *application.Service) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			JSONError(rw, errorResp{Message: "Something went wrong..."}, http.StatusInternalServerError)
+			return
+		}
+
+		conn, err := grpc.Dial(":9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		defer conn.Close()
+
+		rp := reposerver.NewRepoServiceClient(conn)
+		vars := mux.Vars(r)
+		field := vars["field"]
+		idAsUInt, err := strconv.ParseUint(vars["id"], 10, 32)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		app, err := applicationService.Get(uint(idAsUInt))
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+
+		repo, err := s.repoService.Get(app.RepoID)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query()
+		message := manifestsRequest(repo, app)
+
+		if query.Has("hash") {
+			message.Hash = query.Get("hash")
+		}
+
+		manifests, err := rp.GetManifests(context.Background(), message)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: status.Convert(err).Message()}, grpcErrorStatus(err))
+			return
+		}
+
+		properties, _ := manifests.Schema.AsMap()["properties"].(map[string]interface{})
+		fieldSchema, _ := properties[field].(map[string]interface{})
+		extension, ok := fieldSchema[client.OPTIONS_EXTENSION]
+
+		if !ok {
+			JSONError(rw, errorResp{Message: "field " + field + " has no " + client.OPTIONS_EXTENSION}, http.StatusNotFound)
+			return
+		}
+
+		source, err := client.ParseOptionSource(extension)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		values := map[string]string{}
+
+		for key := range query {
+			if !client.Contains(optionsParams, key) {
+				values[key] = query.Get(key)
+			}
+		}
+
+		rendered, err := source.Render(values)
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusBadRequest)
+			return
+		}
+
+		var options []client.Option
+
+		if rendered.Kubernetes != nil {
+			clusterId, _ := strconv.ParseUint(query.Get("cluster"), 10, 32)
+			cluster, kube, clusterErr := s.clusterFor(app, uint(clusterId))
+
+			if clusterErr != nil {
+				JSONError(rw, errorResp{Message: clusterErr.Error()}, http.StatusBadRequest)
+				return
+			}
+
+			impersonate, authErr := s.impersonation(r)
+
+			if authErr != nil {
+				JSONError(rw, errorResp{Message: authErr.Error()}, http.StatusUnauthorized)
+				return
+			}
+
+			key, _ := json.Marshal([]interface{}{app.ID, field, cluster, impersonate, rendered})
+			options, err = s.cachedOptions(string(key), rendered, func() ([]client.Option, error) {
+				return kube.ListOptions(r.Context(), rendered, impersonate)
+			})
+		} else {
+			key, _ := json.Marshal([]interface{}{app.ID, field, rendered})
+			options, err = s.cachedOptions(string(key), rendered, func() ([]client.Option, error) {
+				return client.FetchOptions(r.Context(), rendered, s.optionsHosts)
+			})
+		}
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, optionsErrorStatus(err))
+			return
+		}
+
+		respBytes, err := json.Marshal(OptionsHttpResponse{Field: field, Options: options})
+
+		if err != nil {
+			JSONError(rw, errorResp{Message: err.Error()}, http.StatusInternalServerError)
+			return
+		}
+
+		io.WriteString(rw, string(respBytes))
+	}
+}
+
+func (s *Server) cachedOptions(key string, source *client.OptionSource, list func() ([]client.Option, error)) ([]client.Option, error) {
+	if options, ok := s.options.Get(key); ok {
+		return options, nil
+	}
+
+	options, err := list()
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.options.Put(key, options, time.Duration(source.Ttl)*time.Second)
+	return options, nil
+}
+
+// optionsErrorStatus reports sources failing to list options as a bad
+// gateway, unless the user may not list them.
+func optionsErrorStatus(err error) int {
+	if status := runErrorStatus(err); status != http.StatusInternalServerError {
+		return status
+	}
+
+	return http.StatusBadGateway
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/infor-design/selfservice/pkg/application"
+	"github.com/infor-design/selfservice/pkg/db"
+	repoPkg "github.com/infor-design/selfservice/pkg/repo"
+	"github.com/infor-design/selfservice/reposerver"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// syncScheduler periodically syncs the repos which are due, running at most
+// a fixed number of syncs at once.
+type syncScheduler struct {
+	mu       sync.Mutex
+	running  map[uint]bool
+	slots    chan struct{}
+	interval time.Duration
+}
+
+func newSyncScheduler(interval time.Duration, concurrency int) *syncScheduler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &syncScheduler{
+		running:  map[uint]bool{},
+		slots:    make(chan struct{}, concurrency),
+		interval: interval,
+	}
+}
+
+func (s *syncScheduler) Run(rp reposerver.RepoServiceClient, repoService *repoPkg.Service, applicationService *application.Service) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		collectCheckouts(rp, repoService)
+		s.syncDue(rp, repoService, applicationService)
+		<-ticker.C
+	}
+}
+
+func (s *syncScheduler) syncDue(rp reposerver.RepoServiceClient, repoService *repoPkg.Service, applicationService *application.Service) {
+	now := time.Now()
+
+	for _, repo := range repoService.List() {
+		if repoPkg.NextSync(repo, s.interval).After(now) || !s.start(repo.ID) {
+			continue
+		}
+
+		go func(repo db.Repo) {
+			s.slots <- struct{}{}
+
+			defer func() {
+				<-s.slots
+				s.finish(repo.ID)
+			}()
+
+			_, err := syncRepo(rp, repoService, applicationService, repo)
+
+			if err != nil {
+				log.Errorf("repo %d: %s", repo.ID, err)
+			}
+		}(repo)
+	}
+}
+
+func (s *syncScheduler) start(repoId uint) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[repoId] {
+		return false
+	}
+
+	s.running[repoId] = true
+	return true
+}
+
+func (s *syncScheduler) finish(repoId uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.running, repoId)
+}
+
+// collectCheckouts has the repo server move checkouts of the previous layout
+// and remove the checkouts of deleted repos.
+func collectCheckouts(rp reposerver.RepoServiceClient, repoService *repoPkg.Service) {
+	repos, err := repoService.ListStrict()
+
+	if err != nil {
+		log.Errorf("collecting checkouts: %s", err)
+		return
+	}
+
+	message := reposerver.CollectRequest{}
+
+	for _, repo := range repos {
+		message.Repos = append(message.Repos, &reposerver.CheckoutRepo{
+			RepoId: strconv.FormatInt(int64(repo.ID), 10),
+			Repo:   repo.Url,
+		})
+	}
+
+	resp, err := rp.CollectCheckouts(context.Background(), &message, grpc.WaitForReady(true))
+
+	if err != nil {
+		log.Errorf("collecting checkouts: %s", err)
+		return
+	}
+
+	for _, moved := range resp.Moved {
+		log.Infof("moved checkout %s", moved)
+	}
+
+	for _, removed := range resp.Removed {
+		log.Infof("removed checkout %s", removed)
+	}
+}
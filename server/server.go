@@ -1,17 +1,19 @@
 package server
 
 import (
-	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/infor-design/selfservice/pkg/client"
+	"github.com/infor-design/selfservice/pkg/cluster"
 	"github.com/infor-design/selfservice/pkg/db"
 	"github.com/infor-design/selfservice/pkg/health"
 	"github.com/infor-design/selfservice/pkg/job"
 	"github.com/infor-design/selfservice/pkg/repo"
 	"github.com/infor-design/selfservice/pkg/utils"
+	"github.com/infor-design/selfservice/pkg/webhook"
 	"github.com/infor-design/selfservice/reposerver"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -22,35 +24,74 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-type ServerConfig struct{}
+type ServerConfig struct {
+	Kube         *client.Config
+	Impersonate  bool
+	UserHeader   string
+	GroupsHeader string
+}
 
 type Server struct {
 	ServerConfig
-	log             *log.Entry
-	logsPath        string
-	refreshInterval int
-	db              *db.Connection
-	clientset       *client.Clientset
-	pods            *client.Client
-	repoService     *repo.Service
-	router          *mux.Router
-	stopCh          chan struct{}
+	log            *log.Entry
+	logsPath       string
+	scheduler      *syncScheduler
+	db             *db.Connection
+	clusters       *client.Clusters
+	clusterService *cluster.Service
+	repoService    *repo.Service
+	webhooks       *webhook.Debouncer
+	options        *client.OptionsCache
+	optionsHosts   []string
+	router         *mux.Router
+	stopCh         chan struct{}
 }
 
 func NewServer(config ServerConfig) *Server {
+	if config.Kube == nil {
+		config.Kube = client.NewConfig()
+	}
+
+	if config.UserHeader == "" {
+		config.UserHeader = utils.GetEnv("AUTH_USER_HEADER", "X-Forwarded-User")
+	}
+
+	if config.GroupsHeader == "" {
+		config.GroupsHeader = utils.GetEnv("AUTH_GROUPS_HEADER", "X-Forwarded-Groups")
+	}
+
+	debounce, _ := strconv.Atoi(utils.GetEnv("WEBHOOK_DEBOUNCE", "5"))
+	interval, err := strconv.Atoi(utils.GetEnv("SYNC_INTERVAL", "15"))
+
+	if err != nil || interval < 1 {
+		log.Warn("SYNC_INTERVAL must be a positive number of minutes, syncing every 15 minutes")
+		interval = 15
+	}
+
+	concurrency, _ := strconv.Atoi(utils.GetEnv("SYNC_CONCURRENCY", "4"))
+	optionsTtl, _ := strconv.Atoi(utils.GetEnv("OPTIONS_CACHE_TTL", "60"))
+	optionsSize, _ := strconv.Atoi(utils.GetEnv("OPTIONS_CACHE_SIZE", "1000"))
+	var optionsHosts []string
+
+	if value := utils.GetEnv("OPTIONS_ALLOWED_HOSTS", ""); value != "" {
+		optionsHosts = strings.Split(value, ",")
+	}
+
 	dbConfig := db.NewConfig()
 	newDb := db.NewDb(dbConfig)
 
 	return &Server{
-		ServerConfig:    config,
-		db:              newDb,
-		log:             log.NewEntry(log.StandardLogger()),
-		logsPath:        utils.GetEnv("LOGS_PATH", ""),
-		refreshInterval: 15,
-		clientset:       client.NewClientset(),
-		pods:            client.NewClient(),
-		repoService:     repo.NewService(newDb),
-		router:          mux.NewRouter().StrictSlash(true),
+		ServerConfig:   config,
+		db:             newDb,
+		log:            log.NewEntry(log.StandardLogger()),
+		logsPath:       utils.GetEnv("LOGS_PATH", ""),
+		scheduler:      newSyncScheduler(time.Duration(interval)*time.Minute, concurrency),
+		clusterService: cluster.NewService(newDb),
+		repoService:    repo.NewService(newDb),
+		webhooks:       webhook.NewDebouncer(time.Duration(debounce) * time.Second),
+		options:        client.NewOptionsCache(time.Duration(optionsTtl)*time.Second, optionsSize),
+		optionsHosts:   optionsHosts,
+		router:         mux.NewRouter().StrictSlash(true),
 	}
 }
 
@@ -62,18 +103,40 @@ func (s *Server) Run() {
 	httpState := health.NewState()
 	jobService := job.NewService(s.db)
 	applicationService := application.NewService(s.db)
-	informer := client.NewInformer(s.clientset, jobService)
+	s.clusters = client.NewClusters(s.Kube, s.clusterService.RestConfig, func(clusterId uint, kube *client.Client, stop chan struct{}) {
+		informer := client.NewInformer(kube.Typed(), jobService)
+		statusInformer := client.NewStatusInformer(kube.Dynamic(), clusterId, jobService, applicationService)
+		go informer.StartInformer(stop)
+		go statusInformer.StartInformer(stop)
+	})
 
 	s.router.HandleFunc("/repos", reposHandler(s.repoService))
-	s.router.HandleFunc("/repos/{id:[0-9]+}", repoHandler(s.repoService))
-	s.router.HandleFunc("/repos/{id:[0-9]+}/{action:[a-z]+}", repoHandler(s.repoService))
+	s.router.HandleFunc("/repos/{id:[0-9]+}", repoHandler(s.repoService, applicationService))
+	s.router.HandleFunc("/repos/{id:[0-9]+}/{action:tree|file}", repoContentHandler(s.repoService))
+	s.router.HandleFunc("/repos/{id:[0-9]+}/sync/events", repoSyncEventsHandler(s.repoService, applicationService))
+	s.router.HandleFunc("/repos/{id:[0-9]+}/promotions", repoPromotionsHandler(s.repoService, applicationService))
+	s.router.HandleFunc("/repos/{id:[0-9]+}/{action:[a-z]+}", repoHandler(s.repoService, applicationService))
 
-	s.router.HandleFunc("/applications", applicationsHandler(applicationService))
+	s.router.HandleFunc("/applications", applicationsHandler(applicationService, s.repoService))
 	s.router.HandleFunc("/applications/{id:[0-9]+}", applicationHandler(applicationService, s.repoService))
+	s.router.HandleFunc("/applications/{id:[0-9]+}/docs", applicationDocsHandler(applicationService, s.repoService))
+	s.router.HandleFunc("/applications/{id:[0-9]+}/validate", applicationValidateHandler(applicationService, s.repoService))
+	s.router.HandleFunc("/applications/{id:[0-9]+}/{action:history|diff}", applicationHistoryHandler(applicationService, s.repoService))
+	s.router.HandleFunc("/applications/{id:[0-9]+}/options/{field}", s.optionsHandler(applicationService))
 	s.router.HandleFunc("/applications/{id:[0-9]+}/jobs", s.applicationJobHandler(applicationService, jobService))
 
 	s.router.HandleFunc("/jobs/{id:[0-9]+}", s.jobHandler(jobService))
 	s.router.HandleFunc("/jobs/{id:[0-9]+}/logs", s.logsHandler())
+	s.router.HandleFunc("/jobs/{id:[0-9]+}/manifests", s.jobManifestsHandler(jobService, applicationService))
+
+	s.router.HandleFunc("/webhooks/git/{provider:[a-z]+}", s.webhookHandler(applicationService))
+
+	s.router.HandleFunc("/known-hosts", knownHostsHandler())
+	s.router.HandleFunc("/known-hosts/{action:[a-z]+}", knownHostsHandler())
+	s.router.HandleFunc("/trusted-keys", trustedKeysHandler())
+
+	s.router.HandleFunc("/clusters", clustersHandler(s.clusterService, s.clusters))
+	s.router.HandleFunc("/clusters/{id:[0-9]+}", clusterHandler(s.clusterService, s.clusters))
 
 	s.router.HandleFunc("/settings", s.settingsHandler())
 	s.router.HandleFunc("/health", httpState.Health)
@@ -92,37 +155,22 @@ func (s *Server) Run() {
 
 	rp := reposerver.NewRepoServiceClient(conn)
 
-	go func() {
-		ticker := time.NewTicker(time.Minute * time.Duration(s.refreshInterval))
-		defer ticker.Stop()
-
-		for range ticker.C {
-			repos := s.repoService.List()
+	go s.scheduler.Run(rp, s.repoService, applicationService)
 
-			for _, repo := range repos {
-				message := reposerver.SyncRequest{Repo: repo.Url, RepoId: strconv.FormatInt(int64(repo.ID), 10)}
-				resp, err := rp.Sync(context.Background(), &message)
+	_, err = s.clusters.Get(client.DefaultCluster)
 
-				if err != nil {
-					log.Errorln(err)
-					continue
-				}
-
-				updateRepo, err := s.repoService.Get(uint(repo.ID))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
 
-				if err != nil {
-					log.Errorln(err)
-					continue
-				}
+	for _, c := range s.clusterService.List() {
+		_, err := s.clusters.Get(uint(c.Id))
 
-				updateRepo.Commit = resp.Commit
-				updateRepo.Hash = resp.Hash
-				s.repoService.Update(updateRepo)
-			}
+		if err != nil {
+			log.Errorf("cluster %s: %v", c.Name, err)
 		}
-	}()
+	}
 
-	go informer.StartInformer()
 	go func() {
 		log.Infof("Starting server...")
 		s.checkServeErr("http", http.ListenAndServe(":8080", nil))
@@ -13,9 +13,61 @@ type RepoResp struct {
 }
 
 type SettingsHttpResponse struct {
-	RepoRoot   string `json:"repo_root"`
-	SshRoot    string `json:"ssh_root"`
-	PrivateKey string `json:"private_key"`
+	SecretStore string           `json:"secret_store"`
+	Keys        []KeyFingerprint `json:"keys"`
+}
+
+type KeyFingerprint struct {
+	RepoId      string `json:"repo_id"`
+	KeyType     string `json:"key_type"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+type FileHttpResponse struct {
+	Hash     string `json:"hash"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+type DocHttpResponse struct {
+	Path string `json:"path"`
+	Html string `json:"html"`
+}
+
+type DocsHttpResponse struct {
+	Hash string            `json:"hash"`
+	Docs []DocHttpResponse `json:"docs"`
+}
+
+type SyncProgressHttpResponse struct {
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+}
+
+type OptionsHttpResponse struct {
+	Field   string          `json:"field"`
+	Options []client.Option `json:"options"`
+}
+
+type WebhookResponse struct {
+	Synced []uint `json:"synced"`
+}
+
+type HostKeyPayload struct {
+	Line        string `json:"line"`
+	Host        string `json:"host"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+type TrustedKeyPayload struct {
+	Key         string `json:"key"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+type PromotionPayload struct {
+	Hash string `json:"hash"`
 }
 
 type AppManifestHttpResp struct {
@@ -29,3 +81,9 @@ type JobRunResponse struct {
 	Spec   v1.JobSpec       `json:"spec"`
 	Status v1.JobStatus     `json:"status"`
 }
+
+type ResourceRunResponse struct {
+	Job      db.Job                 `json:"job"`
+	Config   client.ResourceConfig  `json:"config"`
+	Resource map[string]interface{} `json:"resource"`
+}
@@ -2,19 +2,32 @@ package server
 
 import (
 	"bufio"
+	"context"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
+	"github.com/infor-design/selfservice/pkg/application"
+	"github.com/infor-design/selfservice/pkg/db"
+	repoPkg "github.com/infor-design/selfservice/pkg/repo"
+	"github.com/infor-design/selfservice/pkg/webhook"
+	"github.com/infor-design/selfservice/reposerver"
 	_ "github.com/lib/pq"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/golang/gddo/httputil/header"
 )
@@ -120,6 +133,22 @@ func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) err
 	return nil
 }
 
+// writeEvent writes a server-sent event with a JSON payload and flushes it.
+func writeEvent(rw http.ResponseWriter, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	fmt.Fprintf(rw, "event: %s\ndata: %s\n\n", event, data)
+
+	if flusher, ok := rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func contentTypeApplicationJsonMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -142,6 +171,208 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// grpcErrorStatus maps errors returned by the repo server to HTTP statuses.
+func grpcErrorStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// syncRepo syncs the ref tracked by a repo, then the refs its applications
+// are pinned to.
+func syncRepo(rp reposerver.RepoServiceClient, repoService *repoPkg.Service, applicationService *application.Service, repo db.Repo) (db.Repo, error) {
+	resp, err := rp.Sync(context.Background(), syncRequest(repo, repo.Ref, applicationService))
+	return recordSync(rp, repoService, applicationService, repo, resp, err)
+}
+
+// syncRequest syncs a ref of a repo with the clone options of the repo.
+// Sparse checkouts check out the directories of its applications.
+func syncRequest(repo db.Repo, ref string, applicationService *application.Service) *reposerver.SyncRequest {
+	message := reposerver.SyncRequest{
+		Repo:             repo.Url,
+		RepoId:           strconv.FormatInt(int64(repo.ID), 10),
+		Ref:              ref,
+		Depth:            int32(repo.CloneDepth),
+		SingleBranch:     repo.SingleBranch,
+		Sparse:           repo.Sparse,
+		Submodules:       repo.Submodules,
+		Lfs:              repo.Lfs,
+		RequireSignature: repo.RequireSignedCommits,
+	}
+
+	if repo.Sparse {
+		for _, app := range applicationService.ListByRepo(repo.ID) {
+			message.SparsePaths = append(message.SparsePaths, app.ManifestPath)
+		}
+	}
+
+	return &message
+}
+
+// updateSparseCheckouts checks out the directories of the applications of a
+// sparse repo, at every ref it is checked out at, as applications change.
+func updateSparseCheckouts(rp reposerver.RepoServiceClient, repo db.Repo, applicationService *application.Service) {
+	if !repo.Sparse {
+		return
+	}
+
+	refs := append([]string{repo.Ref}, repoPkg.PinnedRefs(repo, applicationService.ListByRepo(repo.ID))...)
+
+	for _, ref := range refs {
+		_, err := rp.UpdateSparseCheckout(context.Background(), syncRequest(repo, ref, applicationService))
+
+		if err != nil {
+			log.Errorf("repo %d at %s: %s", repo.ID, ref, err)
+		}
+	}
+}
+
+// recordSync records the outcome of syncing the ref tracked by a repo, then
+// syncs the refs its applications are pinned to.
+func recordSync(rp reposerver.RepoServiceClient, repoService *repoPkg.Service, applicationService *application.Service, repo db.Repo, resp *reposerver.SyncResponse, err error) (db.Repo, error) {
+	if err != nil {
+		_, recordErr := repoService.RecordSync(repo, "", "", "", errors.New(status.Convert(err).Message()))
+
+		if recordErr != nil {
+			log.Errorln(recordErr)
+		}
+
+		return repo, err
+	}
+
+	repo, err = repoService.RecordSync(repo, resp.Hash, resp.Commit, resp.Signer, nil)
+
+	if err != nil {
+		return repo, err
+	}
+
+	// The applications of a pending commit are reconciled when it is promoted.
+	if repo.Hash == resp.Hash {
+		err = applicationService.Reconcile(repo.ID, descriptors(repo, resp.Applications))
+
+		if err != nil {
+			log.Errorf("repo %d: %s", repo.ID, err)
+		}
+	}
+
+	for _, ref := range repoPkg.PinnedRefs(repo, applicationService.ListByRepo(repo.ID)) {
+		_, err := rp.Sync(context.Background(), syncRequest(repo, ref, applicationService))
+
+		if err != nil {
+			log.Errorf("repo %d at %s: %s", repo.ID, ref, err)
+		}
+	}
+
+	// Applications discovered by this sync were not checked out yet.
+	updateSparseCheckouts(rp, repo, applicationService)
+	return repo, nil
+}
+
+// pushUpdates reports whether a push moved the ref followed by a repo or by
+// one of its applications.
+func pushUpdates(push *webhook.Push, repo db.Repo, applicationService *application.Service) bool {
+	if push.Updates(repo.Ref) {
+		return true
+	}
+
+	for _, app := range applicationService.ListByRepo(repo.ID) {
+		if app.Ref != "" && push.Updates(app.Ref) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileResponse returns text files as is and other files base64 encoded.
+func fileResponse(file *reposerver.FileResponse) FileHttpResponse {
+	resp := FileHttpResponse{Hash: file.Hash, Path: file.Path, Size: file.Size, Encoding: "utf-8", Content: string(file.Content)}
+
+	if !utf8.Valid(file.Content) {
+		resp.Encoding = "base64"
+		resp.Content = base64.StdEncoding.EncodeToString(file.Content)
+	}
+
+	return resp
+}
+
+func descriptors(repo db.Repo, applications []*reposerver.AppDescriptor) []application.Descriptor {
+	var descriptors []application.Descriptor
+
+	for _, app := range applications {
+		if app.Error != "" {
+			log.Warnf("repo %d: %s", repo.ID, app.Error)
+		}
+
+		descriptors = append(descriptors, application.Descriptor{
+			Source:       app.Source,
+			Name:         app.Name,
+			Description:  app.Description,
+			Icon:         app.Icon,
+			Owners:       app.Owners,
+			Namespace:    app.Namespace,
+			ManifestPath: app.ManifestPath,
+			Invalid:      app.Error != "",
+		})
+	}
+
+	return descriptors
+}
+
+// manifestsRequest reads the manifests of an application at its ref, see
+// repo.ManifestsRef.
+func manifestsRequest(repo db.Repo, app db.Application) *reposerver.ManifestsRequest {
+	return &reposerver.ManifestsRequest{
+		Path:    app.ManifestPath,
+		Repo:    repo.Url,
+		RepoId:  strconv.FormatInt(int64(repo.ID), 10),
+		Ref:     repoPkg.ManifestsRef(repo, app),
+		Hash:    promotedHash(repo),
+		Options: repoOptions(repo),
+	}
+}
+
+// repoOptions are the clone options of a repo, which checkouts created to
+// serve reads are cloned with.
+func repoOptions(repo db.Repo) *reposerver.RepoOptions {
+	return &reposerver.RepoOptions{
+		Depth:            int32(repo.CloneDepth),
+		SingleBranch:     repo.SingleBranch,
+		Sparse:           repo.Sparse,
+		Submodules:       repo.Submodules,
+		Lfs:              repo.Lfs,
+		RequireSignature: repo.RequireSignedCommits,
+	}
+}
+
+// promotedHash is the commit the manifests of an application are served at
+// when its repo requires promotion. Applications of such repos cannot be
+// pinned to another ref, and are served at the promoted commit if they were.
+func promotedHash(repo db.Repo) string {
+	if !repo.RequirePromotion {
+		return ""
+	}
+
+	return repo.Hash
+}
+
+// authMethod picks the authentication method of a repo payload. Payloads
+// which only carry an SSH key keep working as before.
+func authMethod(method string, sshKey string) string {
+	if method == "" && len(sshKey) > 0 {
+		return reposerver.AUTH_SSH
+	}
+
+	return method
+}
+
 func GenerateRandomString(n int) (string, error) {
 	const letters = "abcdefghijklmnopqrstuvwxyz-"
 	ret := make([]byte, n)
@@ -182,3 +413,63 @@ func statTimes(name string) (atime, mtime, ctime time.Time, err error) {
 	ctime = time.Unix(int64(stat.Ctimespec.Sec), int64(stat.Ctimespec.Nsec))
 	return
 }
+
+// addClusterInput adds a cluster picker to the form of an application bound to
+// more than one cluster. The picked cluster is posted as "cluster" with the job.
+func addClusterInput(manifests *reposerver.ManifestsResponse, clusters []db.Cluster) {
+	if manifests == nil || manifests.Schema == nil || len(clusters) < 2 {
+		return
+	}
+
+	options := make([]interface{}, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		options = append(options, map[string]interface{}{
+			"const": cluster.ID,
+			"title": cluster.Name,
+		})
+	}
+
+	input, err := structpb.NewValue(map[string]interface{}{
+		"type":  "integer",
+		"title": "Cluster",
+		"oneOf": options,
+	})
+
+	if err != nil {
+		return
+	}
+
+	if manifests.Schema.Fields == nil {
+		manifests.Schema.Fields = map[string]*structpb.Value{}
+	}
+
+	properties := manifests.Schema.Fields["properties"].GetStructValue()
+
+	if properties == nil {
+		properties = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		manifests.Schema.Fields["properties"] = structpb.NewStructValue(properties)
+	}
+
+	properties.Fields["cluster"] = input
+
+	if manifests.Data == nil {
+		manifests.Data = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	}
+
+	if _, ok := manifests.Data.Fields["cluster"]; !ok {
+		manifests.Data.Fields["cluster"] = structpb.NewNumberValue(float64(clusters[0].ID))
+	}
+
+	order := manifests.UiSchema.GetFields()["ui:order"].GetListValue()
+
+	if order != nil {
+		for _, value := range order.Values {
+			if value.GetStringValue() == "*" || value.GetStringValue() == "cluster" {
+				return
+			}
+		}
+
+		order.Values = append([]*structpb.Value{structpb.NewStringValue("cluster")}, order.Values...)
+	}
+}
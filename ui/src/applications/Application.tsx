@@ -8,7 +8,7 @@ import ApplicationForm from "./ApplicationForm";
 import Drawer from "../globals/Drawer";
 import { useSnackbar } from "notistack";
 import { FormikValues } from "formik";
-import { fetchRepos } from "../requests/repos";
+import { fetchRepos, streamRepoSync } from "../requests/repos";
 import { getErrorMessage } from "../requests/utils";
 import {
   Button,
@@ -26,6 +26,7 @@ const Application = () => {
   const [application, setApplication] = useState<ApplicationFull>();
   const [updating, setUpdating] = useState<boolean>(false);
   const [deleting, setDelelting] = useState<boolean>(false);
+  const [syncing, setSyncing] = useState<boolean>(false);
   const [crumbs, setCrumbs] = useState<Crumb[]>([]);
   const [formValid, setFormValid] = useState(false);
   const [formDefaults, setFormDefaults] = useState<any>();
@@ -85,6 +86,32 @@ const Application = () => {
     }
   };
 
+  const handleSync = () => {
+    if (application?.app) {
+      setSyncing(true);
+      streamRepoSync(String(application.app.repo_id), (progress) => {
+        if (!progress.message) {
+          enqueueSnackbar(`${progress.phase}...`, {
+            variant: "info",
+          });
+        }
+      })
+        .then((repo) => {
+          enqueueSnackbar(`Synced ${repo.url} at ${repo.hash}`, {
+            variant: "success",
+          });
+        })
+        .catch((err) => {
+          enqueueSnackbar(getErrorMessage(err), {
+            variant: "error",
+          });
+        })
+        .finally(() => {
+          setSyncing(false);
+        });
+    }
+  };
+
   useEffect(() => {
     if (application?.app) {
       setCrumbs([
@@ -109,6 +136,7 @@ const Application = () => {
         setFormDefaults({
           name: data.app.name,
           manifest_path: data.app.manifest_path,
+          ref: data.app.ref,
           repo_id: data.app.repo_id,
         });
       });
@@ -163,9 +191,11 @@ const Application = () => {
                 appId={appId}
                 update={handleUpdate}
                 del={handleDelete}
+                sync={handleSync}
                 formValid={formValid}
                 updating={updating}
                 deleting={deleting}
+                syncing={syncing}
               />
             }
             body={
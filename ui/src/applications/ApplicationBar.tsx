@@ -9,16 +9,20 @@ function ApplicationBar({
   appId,
   updating,
   deleting,
+  syncing,
   formValid,
   update,
   del,
+  sync,
 }: {
   appId: string;
   updating: boolean;
   deleting: boolean;
+  syncing: boolean;
   formValid: boolean;
   update: () => void;
   del: () => void;
+  sync: () => void;
 }) {
   return (
     <>
@@ -62,6 +66,18 @@ function ApplicationBar({
           </IconButton>
         </Box>
 
+        <Box>
+          <LoadingButton
+            loading={!!syncing}
+            onClick={sync}
+            variant="outlined"
+            color="inherit"
+            sx={{ ml: 1, mr: 1 }}
+          >
+            Sync repo
+          </LoadingButton>
+        </Box>
+
         <Box>
           <LoadingButton
             disabled={!formValid}
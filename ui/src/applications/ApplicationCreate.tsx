@@ -59,6 +59,7 @@ const ApplicationCreate = () => {
     setFormDefaults({
       name: "",
       manifest_path: "",
+      ref: "",
       repo_id: "",
     });
   }, []);
@@ -136,6 +136,22 @@ const ApplicationForm = ({
               </FormHelperText>
             )}
           </FormControl>
+
+          <FormControl fullWidth sx={{ mb: 2 }}>
+            <InputLabel htmlFor="ref">Branch, tag or commit</InputLabel>
+            <OutlinedInput
+              id="ref"
+              name="ref"
+              label="Branch, tag or commit"
+              value={formik.values?.ref || ""}
+              onChange={formik.handleChange}
+              onBlur={formik.handleBlur}
+            />
+
+            <FormHelperText id="ref-helper-text">
+              Leave empty to follow the ref tracked by the repo
+            </FormHelperText>
+          </FormControl>
         </Box>
       </Container>
     </>
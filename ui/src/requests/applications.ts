@@ -1,5 +1,5 @@
 import { deleteRequest, parseOrThrowRequest, post, put } from "./utils";
-import { Application, RunStatus, FormData, ApplicationFull } from "../types";
+import { Application, RunStatus, FormData, ApplicationFull, FieldOptions } from "../types";
 import { SERVER_URL } from "../constants";
 
 export const createApplication = async (values: Partial<any>) => {
@@ -36,3 +36,19 @@ export const startJob = async (id: number, data: FormData) => {
   const url = `${SERVER_URL}/applications/${id}/jobs`;
   return (await post(url, data)) as Promise<RunStatus>;
 };
+
+export const fetchFieldOptions = async (
+  id: number,
+  field: string,
+  values: Record<string, string>,
+  hash?: string
+) => {
+  const params = new URLSearchParams(values);
+
+  if (hash) {
+    params.set("hash", hash);
+  }
+
+  const url = `${SERVER_URL}/applications/${id}/options/${encodeURIComponent(field)}?${params}`;
+  return (await parseOrThrowRequest(url)) as Promise<FieldOptions>;
+};
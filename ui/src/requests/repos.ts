@@ -1,5 +1,5 @@
 import { deleteRequest, parseOrThrowRequest, post, put } from "./utils";
-import { Repo, RepoCreate } from "../types";
+import { Repo, RepoCreate, SyncProgress } from "../types";
 import { SERVER_URL } from "../constants";
 
 export const fetchRepos = async () => {
@@ -27,6 +27,48 @@ export const syncRepo = async (id: string) => {
   return (await post(url, {})) as Promise<Repo>;
 };
 
+/**
+ * Syncs a repo, reporting the phases of the sync and the progress messages of
+ * the git remote as they arrive. Resolves with the synced repo.
+ * @param {string} id - Id of the repo
+ * @param {function} onProgress - Called with each progress event
+ */
+export const streamRepoSync = async (id: string, onProgress: (progress: SyncProgress) => void) => {
+  const res = await fetch(`${SERVER_URL}/repos/${id}/sync/events`, { method: "POST" });
+
+  if (!res.ok || !res.body) throw res;
+
+  const reader = res.body.getReader();
+  const decoder = new TextDecoder();
+  let buffer = "";
+
+  for (;;) {
+    const { value, done } = await reader.read();
+
+    if (done) throw new Error("sync ended without a result");
+
+    buffer += decoder.decode(value, { stream: true });
+    const events = buffer.split("\n\n");
+    buffer = events.pop() ?? "";
+
+    for (const block of events) {
+      const lines = block.split("\n");
+      const event = lines.find((line) => line.startsWith("event: "))?.slice("event: ".length);
+      const data = JSON.parse(lines.find((line) => line.startsWith("data: "))?.slice("data: ".length) ?? "null");
+
+      if (event === "progress") {
+        onProgress(data);
+      } else if (event === "done") {
+        reader.cancel();
+        return data as Repo;
+      } else if (event === "error") {
+        reader.cancel();
+        throw new Error(data?.message ?? "sync failed");
+      }
+    }
+  }
+};
+
 export const deleteRepo = async (id: string) => {
   const url = `${SERVER_URL}/repos/${id}`;
   return (await deleteRequest(url, {})) as Promise<any>;
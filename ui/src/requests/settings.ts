@@ -1,7 +1,18 @@
 import { parseOrThrowRequest } from "./utils";
 import { SERVER_URL } from "../constants";
 
+export type KeyFingerprint = {
+  repo_id: string;
+  key_type: string;
+  fingerprint: string;
+};
+
+export type SettingsResponse = {
+  secret_store: string;
+  keys: KeyFingerprint[];
+};
+
 export const fetchSettings = async () => {
   const url = `${SERVER_URL}/settings`;
-  return (await parseOrThrowRequest(url)) as Promise<Record<string, string>>;
+  return (await parseOrThrowRequest(url)) as Promise<SettingsResponse>;
 };
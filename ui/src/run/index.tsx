@@ -8,6 +8,7 @@ import _ from "lodash";
 import { Alert, Container } from "@mui/material";
 import { useSnackbar } from "notistack";
 import { getErrorMessage } from "../requests/utils";
+import useFieldOptions from "./useFieldOptions";
 
 const Public = () => {
   const { appId } = useParams<{ appId: string }>();
@@ -16,6 +17,12 @@ const Public = () => {
   const [loading, setLoading] = useState<boolean>(false);
   const [jobId, setJobId] = useState<number | null>(null);
   const { enqueueSnackbar } = useSnackbar();
+  const schema = useFieldOptions(
+    application?.app.id,
+    application?.manifests?.schema,
+    formData,
+    application?.manifests?.hash
+  );
 
   const handleFormChange = (data: FormData) => {
     setFormData(data);
@@ -31,6 +38,7 @@ const Public = () => {
         }
       }
 
+      _.set(obj, "manifest_hash", application.manifests?.hash);
       setLoading(true);
 
       startJob(application.app.id, obj)
@@ -72,7 +80,7 @@ const Public = () => {
             <Container sx={{ mt: 10, mb: 2, p: 0 }} maxWidth="sm">
               <ApplicationForm
                 defaultData={application.manifests.data}
-                schema={application.manifests.schema}
+                schema={schema}
                 uiSchema={application.manifests.ui_schema}
                 handleFormChange={handleFormChange}
               />
@@ -0,0 +1,81 @@
+import _ from "lodash";
+import { useEffect, useMemo, useState } from "react";
+import { RJSFSchema } from "@rjsf/utils";
+import { fetchFieldOptions } from "../requests/applications";
+import { FormData } from "../types";
+
+const OPTIONS_EXTENSION = "x-selfservice-options";
+const FIELD_REFERENCE = /\{\{\s*([^{}\s]+)\s*\}\}/g;
+
+// dependencies lists the fields an option source depends on, declared or
+// referenced as {{field}}.
+const dependencies = (source: any): string[] => {
+  const fields = new Set<string>(source.dependsOn || []);
+
+  JSON.stringify(source).replace(FIELD_REFERENCE, (reference: string, field: string) => {
+    fields.add(field);
+    return reference;
+  });
+
+  return Array.from(fields);
+};
+
+// useFieldOptions resolves the x-selfservice-options of the schema fields
+// into choices, fetching them again when the fields they depend on change.
+const useFieldOptions = (
+  appId: number | undefined,
+  schema: RJSFSchema | undefined,
+  formData: FormData | undefined,
+  hash?: string
+) => {
+  const [options, setOptions] = useState<Record<string, any[]>>({});
+  const sources = useMemo(() => {
+    const properties: Record<string, any> = (schema?.properties as any) || {};
+    return Object.keys(properties)
+      .filter((field) => properties[field]?.[OPTIONS_EXTENSION])
+      .map((field) => ({ field, dependsOn: dependencies(properties[field][OPTIONS_EXTENSION]) }));
+  }, [schema]);
+  const values = sources.map(({ field, dependsOn }) => ({
+    field,
+    values: _.fromPairs(dependsOn.map((dependency) => [dependency, `${formData?.[dependency] ?? ""}`])),
+  }));
+  const key = JSON.stringify(values);
+
+  useEffect(() => {
+    if (!appId) {
+      return;
+    }
+
+    values.forEach(({ field, values }) => {
+      if (Object.values(values).some((value) => value === "")) {
+        setOptions((current) => ({ ...current, [field]: [] }));
+        return;
+      }
+
+      fetchFieldOptions(appId, field, values, hash)
+        .then((resp) => setOptions((current) => ({ ...current, [field]: resp.options })))
+        .catch(() => setOptions((current) => ({ ...current, [field]: [] })));
+    });
+  }, [appId, hash, key]);
+
+  return useMemo(() => {
+    if (!schema || sources.length === 0) {
+      return schema;
+    }
+
+    const resolved: any = _.cloneDeep(schema);
+
+    sources.forEach(({ field }) => {
+      if (options[field]?.length) {
+        resolved.properties[field].oneOf = options[field].map((option) => ({
+          const: option.value,
+          title: option.label,
+        }));
+      }
+    });
+
+    return resolved as RJSFSchema;
+  }, [schema, sources, options]);
+};
+
+export default useFieldOptions;
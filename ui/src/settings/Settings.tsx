@@ -2,12 +2,12 @@ import Drawer from "../globals/Drawer";
 import SettingsBar from "./SettingsBar";
 import { Crumb, Crumbs } from "../Crumbs";
 import { useEffect, useState } from "react";
-import { fetchSettings } from "../requests/settings";
+import { fetchSettings, SettingsResponse } from "../requests/settings";
 import { Typography } from "@mui/material";
 
 const Settings = () => {
   const [crumbs, setCrumbs] = useState<Crumb[]>([]);
-  const [settings, setSettings] = useState<Record<string, string>>();
+  const [settings, setSettings] = useState<SettingsResponse>();
 
   useEffect(() => {
     setCrumbs([
@@ -36,16 +36,14 @@ const Settings = () => {
             {settings && (
               <>
                 <Typography variant="body1" gutterBottom>
-                  REPO_ROOT: {settings?.repo_root}
+                  SECRET_STORE: {settings.secret_store}
                 </Typography>
 
-                <Typography variant="body1" gutterBottom>
-                  SSH_ROOT: {settings?.ssh_root}
-                </Typography>
-
-                <Typography variant="body1" gutterBottom>
-                  PRIVATE_KEY: {settings?.private_key}
-                </Typography>
+                {settings.keys.map((key) => (
+                  <Typography variant="body1" gutterBottom key={key.repo_id}>
+                    REPO {key.repo_id}: {key.key_type} {key.fingerprint}
+                  </Typography>
+                ))}
               </>
             )}
           </>
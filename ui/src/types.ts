@@ -11,6 +11,7 @@ export type Application = {
   name: string;
   repo_id: string;
   manifest_path: any;
+  ref: string;
   created_at: string;
   updated_at: string;
   deleted_at: string;
@@ -22,18 +23,38 @@ export type ApplicationFull = {
     data: FormData;
     schema: RJSFSchema;
     ui_schema: Schema;
+    hash?: string;
+    ref?: string;
+    templates?: Record<string, string>;
   };
 };
 
+export type FieldOption = {
+  value: string;
+  label: string;
+};
+
+export type FieldOptions = {
+  field: string;
+  options: FieldOption[];
+};
+
 export type Repo = {
   id: number;
   url: string;
   commit: string;
   hash: string;
+  ref: string;
+};
+
+export type SyncProgress = {
+  phase: string;
+  message?: string;
 };
 
 export type RepoCreate = {
   url: string;
+  ref?: string;
   ssh_private_key: string;
 };
 
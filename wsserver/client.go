@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
-	"github.com/infor-design/selfservice/pkg/client"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -70,8 +69,6 @@ func getAction(event string) string {
 // ensures that there is at most one reader on a connection by executing all
 // reads from this goroutine.
 func (c *Client) readPump() {
-	clientSet := client.NewClient()
-
 	defer func() {
 		joinRoom := JoinRoom{client: c}
 		c.hub.leaveRoom <- joinRoom
@@ -125,7 +122,7 @@ func (c *Client) readPump() {
 			go func(ctx context.Context) {
 				input := make(chan string)
 				stop := make(chan interface{})
-				go clientSet.StreamPodLogs(ctxWithCancel, input, stop, resName, resNamespace)
+				go c.hub.kube.StreamPodLogs(ctxWithCancel, input, stop, resName, resNamespace)
 
 				for {
 					select {
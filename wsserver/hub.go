@@ -1,5 +1,7 @@
 package wsserver
 
+import "github.com/infor-design/selfservice/pkg/client"
+
 type Message struct {
 	data []byte
 }
@@ -14,6 +16,7 @@ type JoinRoom struct {
 }
 
 type Hub struct {
+	kube            *client.Client
 	clients         map[*Client]bool
 	rooms           map[string]map[*Client]bool
 	joinRoom        chan JoinRoom
@@ -24,8 +27,9 @@ type Hub struct {
 	unregister      chan *Client
 }
 
-func newHub() *Hub {
+func newHub(kube *client.Client) *Hub {
 	return &Hub{
+		kube:            kube,
 		clients:         make(map[*Client]bool),
 		rooms:           make(map[string]map[*Client]bool),
 		joinRoom:        make(chan JoinRoom),
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"net/http"
 
+	"github.com/infor-design/selfservice/pkg/client"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/gorilla/mux"
@@ -11,9 +12,11 @@ import (
 
 var addr = flag.String("addr", ":9090", "http service address")
 
-func Run() {
+// Run serves the pod logs of jobs over websockets, read with the client the
+// command built from its kubeconfig flags.
+func Run(kube *client.Client) {
 	flag.Parse()
-	hub := newHub()
+	hub := newHub(kube)
 	go hub.run()
 	router := mux.NewRouter()
 